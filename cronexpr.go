@@ -16,8 +16,8 @@ package cronexpr
 /******************************************************************************/
 
 import (
-	"fmt"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -25,6 +25,9 @@ import (
 
 // A Expression represents a specific cron time expression as defined at
 // <https://github.com/gorhill/cronexpr#implementation>
+//
+// An Expression is never modified once returned by Parse, thus a single
+// Expression can safely be used by multiple goroutines simultaneously.
 type Expression struct {
 	expression             string
 	secondList             []int
@@ -35,13 +38,25 @@ type Expression struct {
 	lastDayOfMonth         bool
 	lastWorkdayOfMonth     bool
 	daysOfMonthRestricted  bool
-	actualDaysOfMonthList  []int
 	monthList              []int
 	daysOfWeek             map[int]bool
 	specificWeekDaysOfWeek map[int]bool
 	lastWeekDaysOfWeek     map[int]bool
 	daysOfWeekRestricted   bool
 	yearList               []int
+	location               *time.Location
+	dstGap                 DSTGapPolicy
+	dstOverlap             DSTOverlapPolicy
+	dialect                Dialect
+	seed                   *string // seed of `H` directives, nil if none
+	names                  *Locale // month and day-of-week names, nil if English
+	strict                 bool    // see WithStrict
+	every                  int64   // `@every` interval in seconds, 0 if none
+	anchor                 int64   // `@every` anchor, in Unix time
+	members                union   // `|`-separated cron expressions, if any
+
+	// Parsed directives of each field, nil for omitted fields, see Describe()
+	directives [yearIndex + 1][]*cronDirective
 }
 
 /******************************************************************************/
@@ -62,85 +77,185 @@ func MustParse(cronLine string) *Expression {
 /******************************************************************************/
 
 // Parse returns a new Expression pointer. An error is returned if a malformed
-// cron expression is supplied.
+// cron expression is supplied, it is always of type *ParseError.
 // See <https://github.com/gorhill/cronexpr#implementation> for documentation
 // about what is a well-formed cron expression from this library's point of
 // view.
+//
+// A `|`-separated list of cron expressions, e.g. `0 9 * * 1-5 | 0 11 * * 0,6`,
+// yields an Expression which fires whenever any of them fires, see Union().
+// A `CRON_TZ=` or `TZ=` prefix leading the list applies to every cron
+// expression of the list which has no prefix of its own, e.g. both
+// `0 9 * * *` and `0 10 * * *` are evaluated in New York time with
+// `CRON_TZ=America/New_York 0 9 * * * | 0 10 * * *`.
 func Parse(cronLine string) (*Expression, error) {
+	return ParseWithOptions(cronLine)
+}
+
+/******************************************************************************/
+
+// An Option alters the way ParseWithOptions interprets a cron expression, or
+// the way the resulting Expression is evaluated.
+type Option func(*parseOptions)
 
-	// Maybe one of the built-in aliases is being used
-	cron := cronNormalizer.Replace(cronLine)
+type parseOptions struct {
+	location   *time.Location
+	dstGap     DSTGapPolicy
+	dstOverlap DSTOverlapPolicy
+	vixieDST   bool
+	dialect    Dialect
+	seed       *string
+	names      *Locale
+	strict     bool
+}
+
+// ParseInLocation is like Parse, except that the cron expression is evaluated
+// in the time zone `loc`, unless it starts with a `CRON_TZ=` or `TZ=` prefix.
+func ParseInLocation(cronLine string, loc *time.Location) (*Expression, error) {
+	return ParseWithOptions(cronLine, WithLocation(loc))
+}
 
-	indices := fieldFinder.FindAllStringIndex(cron, -1)
-	fieldCount := len(indices)
+// WithLocation causes the cron expression to be evaluated in the time zone
+// `loc`, unless it starts with a `CRON_TZ=` or `TZ=` prefix.
+func WithLocation(loc *time.Location) Option {
+	return func(opts *parseOptions) {
+		opts.location = loc
+	}
+}
+
+// ParseWithOptions is like Parse, with the supplied options applied.
+func ParseWithOptions(cronLine string, options ...Option) (*Expression, error) {
+	if strings.IndexByte(cronLine, '|') >= 0 {
+		return parseUnion(cronLine, options)
+	}
+
+	var opts parseOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	fields, tz := splitFields(cronLine)
+	if len(fields) > 0 && fields[0].s == "@every" {
+		return parseEvery(cronLine, fields, tz, &opts)
+	}
+	fieldCount := len(fields)
 	if fieldCount < 5 {
-		return nil, fmt.Errorf("missing field(s)")
+		return nil, &ParseError{
+			Kind:  ErrorMissingFields,
+			Input: cronLine,
+			Begin: len(cronLine),
+			End:   len(cronLine),
+		}
 	}
 	// ignore fields beyond 7th
 	if fieldCount > 7 {
+		if opts.strict {
+			return nil, newExtraFieldsError(fields[7:], cronLine)
+		}
 		fieldCount = 7
 	}
 
-	var expr = Expression{}
+	var expr = Expression{expression: cronLine, location: opts.location, dialect: opts.dialect, seed: opts.seed, names: opts.names, strict: opts.strict}
 	var field = 0
 	var err error
 
-	// second field (optional)
-	if fieldCount == 7 {
-		err = expr.secondFieldHandler(cron[indices[field][0]:indices[field][1]])
+	// time zone (optional)
+	if tz != nil {
+		expr.location, err = parseLocation(tz, cronLine)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 6 fields start with the second field as per Quartz, or end with the
+	// year field otherwise
+	hasSeconds := fieldCount == 7 || (fieldCount == 6 && opts.dialect == DialectQuartz)
+
+	// `?` placement rules
+	if opts.dialect == DialectQuartz || opts.strict {
+		descs := []fieldDescriptor{minuteDescriptor, hourDescriptor, domDescriptor, monthDescriptor, dowDescriptor, yearDescriptor}
+		if hasSeconds {
+			descs = append([]fieldDescriptor{secondDescriptor}, descs...)
+		}
+		err = checkQuestionMarks(fields[:fieldCount], descs, cronLine)
 		if err != nil {
 			return nil, err
 		}
+	}
+
+	// second field (optional)
+	if hasSeconds {
+		err = expr.secondFieldHandler(fields[field].s)
+		if err != nil {
+			return nil, fields[field].relocate(err, cronLine)
+		}
 		field += 1
 	} else {
 		expr.secondList = []int{0}
 	}
 
 	// minute field
-	err = expr.minuteFieldHandler(cron[indices[field][0]:indices[field][1]])
+	minuteField := fields[field].s
+	err = expr.minuteFieldHandler(minuteField)
 	if err != nil {
-		return nil, err
+		return nil, fields[field].relocate(err, cronLine)
 	}
 	field += 1
 
 	// hour field
-	err = expr.hourFieldHandler(cron[indices[field][0]:indices[field][1]])
+	hourField := fields[field].s
+	err = expr.hourFieldHandler(hourField)
 	if err != nil {
-		return nil, err
+		return nil, fields[field].relocate(err, cronLine)
 	}
 	field += 1
 
 	// day of month field
-	err = expr.domFieldHandler(cron[indices[field][0]:indices[field][1]])
+	domField := &fields[field]
+	err = expr.domFieldHandler(fields[field].s)
 	if err != nil {
-		return nil, err
+		return nil, fields[field].relocate(err, cronLine)
 	}
 	field += 1
 
 	// month field
-	err = expr.monthFieldHandler(cron[indices[field][0]:indices[field][1]])
+	err = expr.monthFieldHandler(fields[field].s)
 	if err != nil {
-		return nil, err
+		return nil, fields[field].relocate(err, cronLine)
 	}
 	field += 1
 
 	// day of week field
-	err = expr.dowFieldHandler(cron[indices[field][0]:indices[field][1]])
+	dowField := &fields[field]
+	err = expr.dowFieldHandler(fields[field].s)
 	if err != nil {
-		return nil, err
+		return nil, fields[field].relocate(err, cronLine)
 	}
 	field += 1
 
 	// year field
 	if field < fieldCount {
-		err = expr.yearFieldHandler(cron[indices[field][0]:indices[field][1]])
+		err = expr.yearFieldHandler(fields[field].s)
 		if err != nil {
-			return nil, err
+			return nil, fields[field].relocate(err, cronLine)
 		}
 	} else {
 		expr.yearList = yearDescriptor.defaultList
 	}
 
+	if opts.strict {
+		err = expr.checkSatisfiable(domField, dowField, cronLine)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// daylight-saving time policy
+	expr.dstGap, expr.dstOverlap = opts.dstGap, opts.dstOverlap
+	if opts.vixieDST {
+		expr.dstGap, expr.dstOverlap = vixieDSTPolicy(minuteField, hourField)
+	}
+
 	return &expr, nil
 }
 
@@ -150,7 +265,8 @@ func Parse(cronLine string) (*Expression, error) {
 // matches the cron expression `expr`.
 //
 // The `time.Location` of the returned time instant is the same as that of
-// `fromTime`.
+// `fromTime`, unless the cron expression has its own time zone, see
+// Location().
 //
 // The zero value of time.Time is returned if no matching time instant exists
 // or if a `fromTime` is itself a zero value.
@@ -159,7 +275,22 @@ func (expr *Expression) Next(fromTime time.Time) time.Time {
 	if fromTime.IsZero() {
 		return fromTime
 	}
+	if expr.location != nil {
+		fromTime = fromTime.In(expr.location)
+	}
+	if expr.members != nil {
+		return expr.members.Next(fromTime)
+	}
+	if expr.every > 0 {
+		return expr.nextEvery(fromTime)
+	}
+	if expr.byZonePeriod(fromTime) {
+		return expr.nextDST(fromTime)
+	}
+	return expr.next(fromTime)
+}
 
+func (expr *Expression) next(fromTime time.Time) time.Time {
 	// Since expr.nextSecond()-expr.nextMonth() expects that the
 	// supplied time stamp is a perfect match to the underlying cron
 	// expression, and since this function is an entry point where `fromTime`
@@ -171,17 +302,12 @@ func (expr *Expression) Next(fromTime time.Time) time.Time {
 	// time stamp.
 
 	// year
-	v := fromTime.Year()
-	i := sort.SearchInts(expr.yearList, v)
-	if i == len(expr.yearList) {
-		return time.Time{}
-	}
-	if v != expr.yearList[i] {
+	if !expr.isYear(fromTime.Year()) {
 		return expr.nextYear(fromTime)
 	}
 	// month
-	v = int(fromTime.Month())
-	i = sort.SearchInts(expr.monthList, v)
+	v := int(fromTime.Month())
+	i := sort.SearchInts(expr.monthList, v)
 	if i == len(expr.monthList) {
 		return expr.nextYear(fromTime)
 	}
@@ -189,18 +315,18 @@ func (expr *Expression) Next(fromTime time.Time) time.Time {
 		return expr.nextMonth(fromTime)
 	}
 
-	expr.actualDaysOfMonthList = expr.calculateActualDaysOfMonth(fromTime.Year(), int(fromTime.Month()))
-	if len(expr.actualDaysOfMonthList) == 0 {
+	actualDaysOfMonthList := expr.calculateActualDaysOfMonth(fromTime.Year(), int(fromTime.Month()))
+	if len(actualDaysOfMonthList) == 0 {
 		return expr.nextMonth(fromTime)
 	}
 
 	// day of month
 	v = fromTime.Day()
-	i = sort.SearchInts(expr.actualDaysOfMonthList, v)
-	if i == len(expr.actualDaysOfMonthList) {
+	i = sort.SearchInts(actualDaysOfMonthList, v)
+	if i == len(actualDaysOfMonthList) {
 		return expr.nextMonth(fromTime)
 	}
-	if v != expr.actualDaysOfMonthList[i] {
+	if v != actualDaysOfMonthList[i] {
 		return expr.nextDayOfMonth(fromTime)
 	}
 	// hour
@@ -241,7 +367,7 @@ func (expr *Expression) Next(fromTime time.Time) time.Time {
 //
 // The time instants in the returned slice are in chronological ascending order.
 // The `time.Location` of the returned time instants is the same as that of
-// `fromTime`.
+// `fromTime`, unless the cron expression has its own time zone.
 //
 // A slice with len between [0-`n`] is returned, that is, if not enough existing
 // matching time instants exist, the number of returned entries will be less
@@ -259,8 +385,234 @@ func (expr *Expression) NextN(fromTime time.Time, n uint) []time.Time {
 			if n == 0 {
 				break
 			}
-			fromTime = expr.nextSecond(fromTime)
+			fromTime = expr.successor(fromTime)
 		}
 	}
 	return nextTimes
 }
+
+/******************************************************************************/
+
+// Between returns all the time instants which match the cron expression
+// `expr`, from `fromTime` inclusively to `toTime` exclusively.
+//
+// The time instants in the returned slice are in chronological ascending order.
+// See All() and Range() for iterating over matching time instants without
+// having them all in memory at once.
+func (expr *Expression) Between(fromTime, toTime time.Time) []time.Time {
+	var times []time.Time
+	if fromTime.IsZero() {
+		return times
+	}
+	for t := expr.Next(fromTime.Add(-time.Nanosecond)); !t.IsZero() && t.Before(toTime); t = expr.successor(t) {
+		times = append(times, t)
+	}
+	return times
+}
+
+// successor returns the closest time instant immediately following `t`, which
+// must be a time instant returned by Next() or Prev().
+func (expr *Expression) successor(t time.Time) time.Time {
+	if expr.members != nil {
+		return expr.members.Next(t)
+	}
+	if expr.every > 0 {
+		return expr.nextEvery(t)
+	}
+	if expr.byZonePeriod(t) {
+		return expr.nextDST(t)
+	}
+	return expr.nextSecond(t)
+}
+
+// predecessor returns the closest time instant immediately preceding `t`,
+// which must be a time instant returned by Next() or Prev().
+func (expr *Expression) predecessor(t time.Time) time.Time {
+	if expr.members != nil {
+		return expr.members.Prev(t)
+	}
+	if expr.every > 0 {
+		return expr.prevEvery(t)
+	}
+	if expr.byZonePeriod(t) {
+		return expr.prevDST(t)
+	}
+	return expr.prev(t)
+}
+
+/******************************************************************************/
+
+// Prev returns the closest time instant immediately preceding `fromTime` which
+// matches the cron expression `expr`.
+//
+// The `time.Location` of the returned time instant is the same as that of
+// `fromTime`, unless the cron expression has its own time zone, see
+// Location().
+//
+// The zero value of time.Time is returned if no matching time instant exists
+// or if a `fromTime` is itself a zero value.
+func (expr *Expression) Prev(fromTime time.Time) time.Time {
+	// Special case
+	if fromTime.IsZero() {
+		return fromTime
+	}
+	if expr.location != nil {
+		fromTime = fromTime.In(expr.location)
+	}
+	if expr.members != nil {
+		return expr.members.Prev(fromTime)
+	}
+	if expr.every > 0 {
+		return expr.prevEvery(fromTime)
+	}
+	if expr.byZonePeriod(fromTime) {
+		return expr.prevDST(fromTime)
+	}
+	return expr.prev(fromTime)
+}
+
+func (expr *Expression) prev(fromTime time.Time) time.Time {
+	t := expr.prevFields(fromTime)
+	// A local time skipped when clocks are set forward is normalized by
+	// `time.Date` past the gap, possibly not before `fromTime`: search again
+	// from the last instant before the gap
+	for !t.IsZero() && !t.Before(fromTime) {
+		start, _ := zoneBounds(t)
+		if start.IsZero() || start.After(fromTime) {
+			start = fromTime
+		}
+		fromTime = start.Add(-time.Nanosecond)
+		t = expr.prevFields(fromTime)
+	}
+	return t
+}
+
+func (expr *Expression) prevFields(fromTime time.Time) time.Time {
+	// Same approach as for Next(): as long as fields of `fromTime` match
+	// the cron expression, keep going, and as soon as a field doesn't
+	// match, move to closest past matching time stamp.
+
+	// year
+	if !expr.isYear(fromTime.Year()) {
+		return expr.prevYear(fromTime)
+	}
+	// month
+	v := int(fromTime.Month())
+	i := sort.SearchInts(expr.monthList, v)
+	if i == len(expr.monthList) || v != expr.monthList[i] {
+		return expr.prevMonth(fromTime)
+	}
+
+	actualDaysOfMonthList := expr.calculateActualDaysOfMonth(fromTime.Year(), int(fromTime.Month()))
+	if len(actualDaysOfMonthList) == 0 {
+		return expr.prevMonth(fromTime)
+	}
+
+	// day of month
+	v = fromTime.Day()
+	i = sort.SearchInts(actualDaysOfMonthList, v)
+	if i == len(actualDaysOfMonthList) || v != actualDaysOfMonthList[i] {
+		return expr.prevDayOfMonth(fromTime)
+	}
+	// hour
+	v = fromTime.Hour()
+	i = sort.SearchInts(expr.hourList, v)
+	if i == len(expr.hourList) || v != expr.hourList[i] {
+		return expr.prevHour(fromTime)
+	}
+	// minute
+	v = fromTime.Minute()
+	i = sort.SearchInts(expr.minuteList, v)
+	if i == len(expr.minuteList) || v != expr.minuteList[i] {
+		return expr.prevMinute(fromTime)
+	}
+	// second
+	v = fromTime.Second()
+	i = sort.SearchInts(expr.secondList, v)
+	if i < len(expr.secondList) && v == expr.secondList[i] && fromTime.Nanosecond() > 0 {
+		// `fromTime` is a fraction of a second past a matching time stamp
+		return fromTime.Truncate(time.Second)
+	}
+
+	return expr.prevSecond(fromTime)
+}
+
+/******************************************************************************/
+
+// PrevN returns a slice of `n` closest time instants immediately preceding
+// `fromTime` which match the cron expression `expr`.
+//
+// The time instants in the returned slice are in chronological descending
+// order. The `time.Location` of the returned time instants is the same as that
+// of `fromTime`, unless the cron expression has its own time zone.
+//
+// A slice with len between [0-`n`] is returned, that is, if not enough existing
+// matching time instants exist, the number of returned entries will be less
+// than `n`.
+func (expr *Expression) PrevN(fromTime time.Time, n uint) []time.Time {
+	prevTimes := make([]time.Time, 0, n)
+	if n > 0 {
+		fromTime = expr.Prev(fromTime)
+		for {
+			if fromTime.IsZero() {
+				break
+			}
+			prevTimes = append(prevTimes, fromTime)
+			n -= 1
+			if n == 0 {
+				break
+			}
+			fromTime = expr.predecessor(fromTime)
+		}
+	}
+	return prevTimes
+}
+
+/******************************************************************************/
+
+// Matches returns whether the time instant `t` is itself one of the time
+// instants matching the cron expression `expr`, that is, whether `t` falls
+// exactly on a scheduled second.
+//
+// `t` is evaluated in its own `time.Location`, unless the cron expression has
+// its own time zone. Matches does not allocate in a time zone with a fixed
+// UTC offset, e.g. UTC, unless a daylight-saving time policy was selected at
+// parse time.
+func (expr *Expression) Matches(t time.Time) bool {
+	if t.IsZero() || t.Nanosecond() != 0 {
+		return false
+	}
+	if expr.members != nil {
+		return expr.matchesUnion(t)
+	}
+	if expr.location != nil {
+		t = t.In(expr.location)
+	}
+	if expr.every > 0 {
+		return expr.matchesEvery(t)
+	}
+	if expr.byZonePeriod(t) {
+		return expr.nextDST(t.Add(-time.Second)).Equal(t)
+	}
+	return expr.isYear(t.Year()) &&
+		containsInt(expr.monthList, int(t.Month())) &&
+		expr.isActualDayOfMonth(t.Year(), int(t.Month()), t.Day()) &&
+		containsInt(expr.hourList, t.Hour()) &&
+		containsInt(expr.minuteList, t.Minute()) &&
+		containsInt(expr.secondList, t.Second())
+}
+
+func containsInt(list []int, v int) bool {
+	i := sort.SearchInts(list, v)
+	return i < len(list) && list[i] == v
+}
+
+/******************************************************************************/
+
+// Location returns the time zone in which the cron expression is evaluated,
+// as specified by a `CRON_TZ=` or `TZ=` prefix or by ParseInLocation. It
+// returns nil if the cron expression has no time zone of its own, in which
+// case it is evaluated in the time zone of the time instants it is given.
+func (expr *Expression) Location() *time.Location {
+	return expr.location
+}
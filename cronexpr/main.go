@@ -31,6 +31,7 @@ var (
 	inTimeStr     string
 	outTimeCount  uint
 	outTimeLayout string
+	lint          bool
 )
 
 /******************************************************************************/
@@ -42,6 +43,7 @@ func main() {
 	flag.StringVar(&inTimeStr, "t", "", `whole or partial RFC3339 time value (i.e. "2006-01-02T15:04:05Z07:00") against which the cron expression is evaluated, now if not present`)
 	flag.UintVar(&outTimeCount, "n", 1, `number of resulting time values to output`)
 	flag.StringVar(&outTimeLayout, "l", "Mon, 02 Jan 2006 15:04:05 MST", `Go-compliant time layout to use for outputting time value(s), see <http://golang.org/pkg/time/#pkg-constants>`)
+	flag.BoolVar(&lint, "lint", false, `output warnings about surprising behaviors of the cron expression`)
 	flag.Parse()
 
 	cronStr := flag.Arg(0)
@@ -92,13 +94,22 @@ func main() {
 
 	expr, err := cronexpr.Parse(cronStr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		if perr, ok := err.(*cronexpr.ParseError); ok {
+			fmt.Fprintf(os.Stderr, "# %s:\n%s\n", os.Args[0], perr.Pretty())
+		} else {
+			fmt.Fprintf(os.Stderr, "# %s: %s\n", os.Args[0], err)
+		}
 		os.Exit(1)
 	}
 
 	// Anything on the output which starts with '#' can be ignored if the caller
 	// is interested only in the time values. There is only one time
 	// value per line, and they are always in chronological ascending order.
+	if lint {
+		for _, warning := range cronexpr.Lint(expr) {
+			fmt.Printf("# warning: %s\n", warning)
+		}
+	}
 	fmt.Printf("# \"%s\" + \"%s\" =\n", cronStr, inTime.Format(time.RFC3339))
 
 	if outTimeCount < 1 {
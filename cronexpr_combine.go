@@ -0,0 +1,201 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_combine.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// union is a Schedule firing whenever any of its members fires.
+type union []Schedule
+
+// intersection is a Schedule firing whenever all of its members fire at the
+// same time instant.
+type intersection []Schedule
+
+// Union returns a Schedule which fires whenever any of `schedules` fires,
+// e.g. "9:00 on weekdays and 11:00 on weekends". Time instants at which
+// several schedules fire are returned only once.
+func Union(schedules ...Schedule) Schedule {
+	return union(append([]Schedule(nil), schedules...))
+}
+
+// Intersect returns a Schedule which fires whenever all of `schedules` fire
+// at the same time instant.
+//
+// Schedules which never coincide are detected only after a bounded number of
+// attempts, see SearchLimit, after which the zero time value is returned.
+func Intersect(schedules ...Schedule) Schedule {
+	return intersection(append([]Schedule(nil), schedules...))
+}
+
+// SearchLimit is the maximum number of candidate time instants which the
+// Next and Prev methods of a Schedule returned by Intersect or Except examine
+// before giving up.
+const SearchLimit = 100000
+
+/******************************************************************************/
+
+func (schedules union) Next(fromTime time.Time) time.Time {
+	var next time.Time
+	for _, s := range schedules {
+		t := s.Next(fromTime)
+		if t.IsZero() == false && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (schedules union) Prev(fromTime time.Time) time.Time {
+	var prev time.Time
+	for _, s := range schedules {
+		t := s.Prev(fromTime)
+		if t.IsZero() == false && (prev.IsZero() || t.After(prev)) {
+			prev = t
+		}
+	}
+	return prev
+}
+
+/******************************************************************************/
+
+func (schedules intersection) Next(fromTime time.Time) time.Time {
+	if len(schedules) == 0 {
+		return time.Time{}
+	}
+	// Move forward to the latest of the next time instants of all members
+	// until they all agree
+	for n := 0; n < SearchLimit; n++ {
+		var latest time.Time
+		agree := true
+		for i, s := range schedules {
+			t := s.Next(fromTime)
+			if t.IsZero() {
+				return t
+			}
+			if i > 0 && t.Equal(latest) == false {
+				agree = false
+			}
+			if i == 0 || t.After(latest) {
+				latest = t
+			}
+		}
+		if agree {
+			return latest
+		}
+		fromTime = latest.Add(-time.Nanosecond)
+	}
+	return time.Time{}
+}
+
+func (schedules intersection) Prev(fromTime time.Time) time.Time {
+	if len(schedules) == 0 {
+		return time.Time{}
+	}
+	for n := 0; n < SearchLimit; n++ {
+		var earliest time.Time
+		agree := true
+		for i, s := range schedules {
+			t := s.Prev(fromTime)
+			if t.IsZero() {
+				return t
+			}
+			if i > 0 && t.Equal(earliest) == false {
+				agree = false
+			}
+			if i == 0 || t.Before(earliest) {
+				earliest = t
+			}
+		}
+		if agree {
+			return earliest
+		}
+		fromTime = earliest.Add(time.Nanosecond)
+	}
+	return time.Time{}
+}
+
+/******************************************************************************/
+
+// parseUnion parses a `|`-separated list of cron expressions into an
+// Expression which fires whenever any of them fires. The time zone prefix of
+// the first cron expression, if any, applies to the others unless they have
+// their own.
+func parseUnion(cronLine string, opts []Option) (*Expression, error) {
+	var expr = Expression{expression: cronLine}
+	beg := 0
+	shared := true
+	for {
+		end := strings.IndexByte(cronLine[beg:], '|')
+		if end < 0 {
+			end = len(cronLine)
+		} else {
+			end += beg
+		}
+		member, err := ParseWithOptions(cronLine[beg:end], opts...)
+		if err != nil {
+			perr := err.(*ParseError)
+			perr.Begin += beg
+			perr.End += beg
+			perr.Input = cronLine
+			return nil, perr
+		}
+		if len(expr.members) == 0 {
+			if _, tz := splitFields(cronLine[beg:end]); tz != nil {
+				opts = append(opts[:len(opts):len(opts)], WithLocation(member.location))
+			}
+		} else if !sameLocation(member.Location(), expr.members[0].(*Expression).Location()) {
+			shared = false
+		}
+		expr.members = append(expr.members, member)
+		if end == len(cronLine) {
+			break
+		}
+		beg = end + 1
+	}
+	// A time zone shared by all cron expressions is that of the union
+	if shared {
+		expr.location = expr.members[0].(*Expression).Location()
+	}
+	return &expr, nil
+}
+
+func sameLocation(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+func (expr *Expression) formatUnion() string {
+	members := make([]string, len(expr.members))
+	for i, member := range expr.members {
+		members[i] = member.(*Expression).String()
+	}
+	return strings.Join(members, " | ")
+}
+
+func (expr *Expression) matchesUnion(t time.Time) bool {
+	for _, member := range expr.members {
+		if member.(*Expression).Matches(t) {
+			return true
+		}
+	}
+	return false
+}
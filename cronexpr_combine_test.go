@@ -0,0 +1,163 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_combine_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+func TestUnion(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-05T12:00:00Z") // Friday
+	for _, s := range []Schedule{
+		Union(MustParse("0 9 * * 1-5"), MustParse("0 11 * * 0,6")),
+		MustParse("0 9 * * 1-5 | 0 11 * * 0,6"),
+	} {
+		next := formatTimes(NextN(s, from, 4))
+		expected := []string{"2024-01-06T11:00:00Z", "2024-01-07T11:00:00Z", "2024-01-08T09:00:00Z", "2024-01-09T09:00:00Z"}
+		if !equalStrings(next, expected) {
+			t.Errorf(`NextN(%v) = %v, expected %v`, s, next, expected)
+		}
+		prev := formatTimes(PrevN(s, from.AddDate(0, 0, 3), 3))
+		expected = []string{"2024-01-08T09:00:00Z", "2024-01-07T11:00:00Z", "2024-01-06T11:00:00Z"}
+		if !equalStrings(prev, expected) {
+			t.Errorf(`PrevN(%v) = %v, expected %v`, s, prev, expected)
+		}
+	}
+}
+
+// Members which coincide must neither cause skipped nor duplicated time
+// instants: compare against a minute by minute scan.
+func TestUnionCoincide(t *testing.T) {
+	a, b := MustParse("*/15 * * * *"), MustParse("*/10 9-17 * * *")
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	var expected []time.Time
+	for t := from; t.Before(to); t = t.Add(time.Minute) {
+		if a.Matches(t) || b.Matches(t) {
+			expected = append(expected, t)
+		}
+	}
+	for _, s := range []Schedule{
+		Union(a, b),
+		Union(b, a, a),
+		MustParse("*/15 * * * * | */10 9-17 * * * | */15 * * * *"),
+	} {
+		result := Between(s, from, to)
+		if len(result) != len(expected) {
+			t.Errorf(`Between(%v) returned %d time instants, expected %d`, s, len(result), len(expected))
+			continue
+		}
+		for i := range result {
+			if !result[i].Equal(expected[i]) {
+				t.Errorf(`Between(%v)[%d] = "%s", expected "%s"`, s, i, result[i], expected[i])
+				break
+			}
+		}
+		// And backward
+		prev := PrevN(s, to, uint(len(expected)+1))
+		if len(prev) != len(expected)+1 || !prev[len(expected)-1].Equal(expected[0]) {
+			t.Errorf(`PrevN(%v) returned %d time instants, expected %d`, s, len(prev), len(expected)+1)
+		}
+	}
+}
+
+func TestUnionParse(t *testing.T) {
+	expr := MustParse("@daily | 30 12 * * MON")
+	if s := expr.String(); s != "0 0 0 * * * * | 0 30 12 * * 1 *" {
+		t.Errorf(`String() = "%s"`, s)
+	}
+	monday := time.Date(2024, time.January, 1, 12, 30, 0, 0, time.UTC)
+	if !expr.Matches(monday) || !expr.Matches(monday.Add(-750*time.Minute)) || expr.Matches(monday.AddDate(0, 0, 1)) {
+		t.Errorf(`Matches() is wrong`)
+	}
+
+	// Members may have their own time zone
+	expr = MustParse("CRON_TZ=Asia/Tokyo 0 9 * * * | CRON_TZ=Europe/Paris 0 9 * * *")
+	next := formatTimes(expr.NextN(monday, 2))
+	expected := []string{"2024-01-02T09:00:00+09:00", "2024-01-02T09:00:00+01:00"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN() = %v, expected %v`, next, expected)
+	}
+
+	if loc := expr.Location(); loc != nil {
+		t.Errorf(`Location() = "%s", expected nil`, loc)
+	}
+
+	// A leading time zone applies to members with none of their own
+	expr = MustParse("CRON_TZ=America/New_York 0 9 * * * | 0 10 * * *")
+	next = formatTimes(expr.NextN(monday, 2))
+	expected = []string{"2024-01-01T09:00:00-05:00", "2024-01-01T10:00:00-05:00"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN() = %v, expected %v`, next, expected)
+	}
+	if loc := expr.Location(); loc == nil || loc.String() != "America/New_York" {
+		t.Errorf(`Location() = "%v", expected "America/New_York"`, loc)
+	}
+	if s := expr.String(); s != "CRON_TZ=America/New_York 0 0 9 * * * * | CRON_TZ=America/New_York 0 0 10 * * * *" {
+		t.Errorf(`String() = "%s"`, s)
+	}
+	expr = MustParse("CRON_TZ=Asia/Tokyo 0 9 * * * | 0 10 * * * | CRON_TZ=Europe/Paris 0 11 * * *")
+	next = formatTimes(expr.NextN(monday, 3))
+	expected = []string{"2024-01-02T09:00:00+09:00", "2024-01-02T10:00:00+09:00", "2024-01-02T11:00:00+01:00"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN() = %v, expected %v`, next, expected)
+	}
+
+	// Errors are relative to the whole line
+	_, err := Parse("0 9 * * 1-5 | 0 11 * * 8")
+	perr, ok := err.(*ParseError)
+	if !ok || perr.Field != "day-of-week" || perr.Begin != 23 || perr.Directive != "8" || perr.Input != "0 9 * * 1-5 | 0 11 * * 8" {
+		t.Errorf(`Parse() returned "%v"`, err)
+	}
+	_, err = Parse("0 9 * * 1-5 |")
+	if perr, ok := err.(*ParseError); !ok || perr.Kind != ErrorMissingFields || perr.Begin != 13 {
+		t.Errorf(`Parse() returned "%v"`, err)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	s := Intersect(MustParse("*/15 * * * *"), MustParse("*/10 * * * *"))
+	next := formatTimes(NextN(s, from, 3))
+	expected := []string{"2024-01-01T00:30:00Z", "2024-01-01T01:00:00Z", "2024-01-01T01:30:00Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Intersect()) = %v, expected %v`, next, expected)
+	}
+	prev := formatTimes(PrevN(s, from, 2))
+	expected = []string{"2023-12-31T23:30:00Z", "2023-12-31T23:00:00Z"}
+	if !equalStrings(prev, expected) {
+		t.Errorf(`PrevN(Intersect()) = %v, expected %v`, prev, expected)
+	}
+
+	// Friday the 13th
+	s = Intersect(MustParse("0 0 13 * *"), MustParse("0 0 * * FRI"))
+	next = formatTimes(NextN(s, from, 2))
+	expected = []string{"2024-09-13T00:00:00Z", "2024-12-13T00:00:00Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Intersect()) = %v, expected %v`, next, expected)
+	}
+
+	// Never coincide
+	s = Intersect(MustParse("0 0 * * MON"), MustParse("0 0 * * TUE"))
+	if next := s.Next(from); !next.IsZero() {
+		t.Errorf(`Intersect().Next() = "%s", expected zero time`, next)
+	}
+	if next := Intersect().Next(from); !next.IsZero() {
+		t.Errorf(`Intersect().Next() = "%s", expected zero time`, next)
+	}
+}
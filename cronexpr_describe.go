@@ -0,0 +1,258 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_describe.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+/******************************************************************************/
+
+// describedField identifies the messages describing the directives of one
+// field, along with the way its values are formatted.
+type describedField struct {
+	key   string // prefix of message IDs, e.g. `minute`
+	desc  fieldDescriptor
+	value func(l *Locale, v int) string
+}
+
+var (
+	secondDescribed = describedField{"second", secondDescriptor, plainValue}
+	minuteDescribed = describedField{"minute", minuteDescriptor, plainValue}
+	hourDescribed   = describedField{"hour", hourDescriptor, func(l *Locale, v int) string { return twoDigits(v) }}
+	domDescribed    = describedField{"dom", domDescriptor, (*Locale).ordinal}
+	monthDescribed  = describedField{"month", monthDescriptor, func(l *Locale, v int) string { return l.MonthName(time.Month(v)) }}
+	dowDescribed    = describedField{"dow", dowDescriptor, func(l *Locale, v int) string { return l.WeekdayName(time.Weekday(v)) }}
+	yearDescribed   = describedField{"year", yearDescriptor, plainValue}
+)
+
+func plainValue(l *Locale, v int) string {
+	return strconv.Itoa(v)
+}
+
+func twoDigits(v int) string {
+	return fmt.Sprintf("%02d", v)
+}
+
+// joinList joins `items` as in "a, b and c".
+func (l *Locale) joinList(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + l.message("and", 1) + items[len(items)-1]
+}
+
+/******************************************************************************/
+
+// Describe returns a plain English description of the cron expression `expr`,
+// e.g. "At 03:30, on the weekday nearest the 15th, every 3 months starting in
+// March" for `0 30 3 15W 3/3 * *`.
+//
+// The description follows the directives of the cron expression as they were
+// written, hence equivalent cron expressions, e.g. `*/20 * * * *` and
+// `0,20,40 * * * *`, may be described differently.
+func (expr *Expression) Describe() string {
+	return expr.DescribeIn(English)
+}
+
+// DescribeIn is like Describe, except that the description is in the language
+// of `locale`, e.g. `cronexpr.French` or `cronexpr.LookupLocale("fr-CA")`. A
+// nil locale stands for English.
+func (expr *Expression) DescribeIn(locale *Locale) string {
+	if locale == nil {
+		locale = English
+	}
+	s := expr.describe(locale)
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func (expr *Expression) describe(l *Locale) string {
+	if expr.members != nil {
+		members := make([]string, len(expr.members))
+		for i, member := range expr.members {
+			members[i] = member.(*Expression).describe(l)
+		}
+		return strings.Join(members, "; ")
+	}
+	var parts []string
+	if expr.every > 0 {
+		every := (time.Duration(expr.every) * time.Second).String()
+		if expr.anchor != 0 {
+			parts = append(parts, fmt.Sprintf(l.message("everyFrom", 1), every, time.Unix(expr.anchor, 0).UTC().Format(time.RFC3339)))
+		} else {
+			parts = append(parts, fmt.Sprintf(l.message("every", 1), every))
+		}
+	} else {
+		parts = append(parts, expr.describeTime(l)...)
+		if days := expr.describeDays(l); days != "" {
+			parts = append(parts, days)
+		}
+		if !isEvery(expr.directives[monthIndex]) {
+			parts = append(parts, l.describeField(expr.directives[monthIndex], monthDescribed))
+		}
+		if expr.directives[yearIndex] != nil && !isEvery(expr.directives[yearIndex]) {
+			parts = append(parts, l.describeField(expr.directives[yearIndex], yearDescribed))
+		}
+	}
+	if expr.location != nil {
+		parts = append(parts, fmt.Sprintf(l.message("timeZone", 1), expr.location.String()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeTime describes the second, minute and hour fields, as a list of
+// times of day when they all consist of single values.
+func (expr *Expression) describeTime(l *Locale) []string {
+	seconds := expr.directives[secondIndex]
+	secondZero := seconds == nil || len(expr.secondList) == 1 && expr.secondList[0] == 0
+	if (secondZero || len(expr.secondList) == 1) && allOnes(expr.directives[minuteIndex]) && allOnes(expr.directives[hourIndex]) &&
+		len(expr.hourList)*len(expr.minuteList) <= 8 {
+		times := make([]string, 0, len(expr.hourList)*len(expr.minuteList))
+		for _, hour := range expr.hourList {
+			for _, minute := range expr.minuteList {
+				s := twoDigits(hour) + ":" + twoDigits(minute)
+				if !secondZero {
+					s += ":" + twoDigits(expr.secondList[0])
+				}
+				times = append(times, s)
+			}
+		}
+		return []string{fmt.Sprintf(l.message("at", len(times)), l.joinList(times))}
+	}
+	var parts []string
+	if !secondZero {
+		parts = append(parts, l.describeField(seconds, secondDescribed))
+	}
+	// "every 10 seconds" goes without saying "every minute"
+	if !isEvery(expr.directives[minuteIndex]) || secondZero {
+		parts = append(parts, l.describeField(expr.directives[minuteIndex], minuteDescribed))
+	}
+	if !isEvery(expr.directives[hourIndex]) {
+		parts = append(parts, l.describeField(expr.directives[hourIndex], hourDescribed))
+	}
+	return parts
+}
+
+// describeDays describes the day-of-month and day-of-week fields, which are
+// combined according to the dialect when both are restricted.
+func (expr *Expression) describeDays(l *Locale) string {
+	var dom, dow string
+	if expr.daysOfMonthRestricted {
+		dom = l.describeField(expr.directives[domIndex], domDescribed)
+	}
+	if expr.daysOfWeekRestricted {
+		dow = l.describeField(expr.directives[dowIndex], dowDescribed)
+	}
+	switch {
+	case dom == "":
+		return dow
+	case dow == "":
+		return dom
+	case expr.daysIntersect():
+		return dom + l.message("and", 1) + dow
+	}
+	return dom + l.message("or", 1) + dow
+}
+
+// describeField describes the directives of one field. Single values are
+// described together, in place of the first of them.
+func (l *Locale) describeField(directives []*cronDirective, field describedField) string {
+	desc := field.desc
+	message := func(id string, n int) string {
+		return l.message(field.key+"."+id, n)
+	}
+	parts := make([]string, 0, len(directives))
+	var ones []string
+	onesAt := -1
+	// `0,7` in the day-of-week field are both Sunday
+	seen := make(map[int]bool)
+	for _, directive := range directives {
+		first, last, step := directive.first, directive.last, directive.step
+		kind := directive.kind
+		// `5-5`
+		if kind == span && first == last {
+			kind = one
+		}
+		switch kind {
+		case all:
+			return message("every", 1)
+		case one:
+			if onesAt < 0 {
+				onesAt = len(parts)
+				parts = append(parts, "")
+			}
+			if !seen[first] {
+				seen[first] = true
+				ones = append(ones, field.value(l, first))
+			}
+		case span:
+			switch {
+			case step == 1 && desc.unwrap(first, last)-first >= desc.max-desc.min:
+				parts = append(parts, message("every", 1))
+			case step == 1:
+				parts = append(parts, fmt.Sprintf(message("span", 2), field.value(l, first), field.value(l, last)))
+			case first == desc.origin && last == desc.max:
+				parts = append(parts, fmt.Sprintf(message("step", step), step))
+			case last == desc.max:
+				parts = append(parts, fmt.Sprintf(message("stepFrom", step), step, field.value(l, first)))
+			default:
+				parts = append(parts, fmt.Sprintf(message("stepSpan", step), step, field.value(l, first), field.value(l, last)))
+			}
+		case lastDom:
+			parts = append(parts, message("last", 1))
+		case lastWorkdom:
+			parts = append(parts, message("lastWeekday", 1))
+		case workdom:
+			parts = append(parts, fmt.Sprintf(message("nearest", 1), field.value(l, first)))
+		case dowOfLastWeek:
+			parts = append(parts, fmt.Sprintf(message("last", 1), field.value(l, first)))
+		case dowOfSpecificWeek:
+			week := l.message("week."+strconv.Itoa(last), 1)
+			parts = append(parts, fmt.Sprintf(message("nth", 1), week, field.value(l, first)))
+		}
+	}
+	if onesAt >= 0 {
+		parts[onesAt] = fmt.Sprintf(message("one", len(ones)), l.joinList(ones))
+	}
+	return strings.Join(parts, l.message("and", 1))
+}
+
+// isEvery returns whether the directives of a field include `*`.
+func isEvery(directives []*cronDirective) bool {
+	for _, directive := range directives {
+		if directive.kind == all {
+			return true
+		}
+	}
+	return false
+}
+
+func allOnes(directives []*cronDirective) bool {
+	for _, directive := range directives {
+		if directive.kind != one {
+			return false
+		}
+	}
+	return len(directives) > 0
+}
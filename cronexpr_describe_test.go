@@ -0,0 +1,78 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_describe_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+)
+
+/******************************************************************************/
+
+type describeTest struct {
+	expr     string
+	expected string
+}
+
+var describeTests = []describeTest{
+	{"0 30 3 15W 3/3 * *", "At 03:30, on the weekday nearest the 15th, every 3 months starting in March"},
+	{"* * * * *", "Every minute"},
+	{"*/15 9-17 * * 1-5", "Every 15 minutes, between 09:00 and 17:59, Monday through Friday"},
+	{"0,30 9,17 * * *", "At 09:00, 09:30, 17:00 and 17:30"},
+	{"15 30 6 * * * *", "At 06:30:15"},
+	{"*/10 * * * * * *", "Every 10 seconds"},
+	{"5-40/5 0 * * * * *", "Every 5 seconds, seconds 5 through 40, at minute 0"},
+	{"7/20 * * * *", "Every 20 minutes starting at minute 7"},
+	{"0 10-20/2 * * *", "At minute 0, every 2 hours between 10:00 and 20:59"},
+	{"0 0 L * *", "At 00:00, on the last day of the month"},
+	{"0 0 LW * *", "At 00:00, on the last weekday of the month"},
+	{"0 0 1,15,L * *", "At 00:00, on the 1st and 15th and on the last day of the month"},
+	{"0 0 * * 5L", "At 00:00, on the last Friday of the month"},
+	{"0 0 * * MON#3,FRI#1", "At 00:00, on the third Monday of the month and on the first Friday of the month"},
+	{"0 0 13 * FRI", "At 00:00, on the 13th or on Friday"},
+	{"0 0 1,2,3,21,22,23 * *", "At 00:00, on the 1st, 2nd, 3rd, 21st, 22nd and 23rd"},
+	{"0 0 1 JAN-JUN *", "At 00:00, on the 1st, January through June"},
+	{"0 0 1 1 * 2025,2027", "At 00:00, on the 1st, in January, in 2025 and 2027"},
+	{"0 0 1 1 * 2025-2031/2", "At 00:00, on the 1st, in January, every 2 years from 2025 through 2031"},
+	{"@weekly", "At 00:00, on Sunday"},
+	{"0 0 * * 0,7", "At 00:00, on Sunday"},
+	{"CRON_TZ=Europe/Paris 0 9 * * *", "At 09:00, in time zone Europe/Paris"},
+	{"0 9 * * 1-5 | 0 11 * * 0,6", "At 09:00, Monday through Friday; at 11:00, on Sunday and Saturday"},
+	{"@every 90m", "Every 1h30m0s"},
+}
+
+func TestDescribe(t *testing.T) {
+	for _, test := range describeTests {
+		if s := MustParse(test.expr).Describe(); s != test.expected {
+			t.Errorf(`("%s").Describe() = "%s", expected "%s"`, test.expr, s, test.expected)
+		}
+	}
+
+	expr, err := ParseWithOptions("0 0 13 * FRI", WithDialect(DialectQuartz))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, expected := expr.Describe(), "At 00:00, on the 13th and on Friday"; s != expected {
+		t.Errorf(`Describe() = "%s", expected "%s"`, s, expected)
+	}
+
+	// `H` directives are described as resolved
+	expr, err = ParseWithSeed("H H(0-5) * * *", "nightly-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, expected := expr.Describe(), "At "+twoDigits(expr.hourList[0])+":"+twoDigits(expr.minuteList[0]); s != expected {
+		t.Errorf(`Describe() = "%s", expected "%s"`, s, expected)
+	}
+}
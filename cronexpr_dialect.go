@@ -0,0 +1,105 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_dialect.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"strconv"
+	"strings"
+)
+
+/******************************************************************************/
+
+// Dialect selects the flavor of cron a cron expression is written in.
+type Dialect int
+
+const (
+	// DialectVixie follows the crontab man page: when both the day-of-month
+	// and day-of-week fields are restricted, a day matches if either field
+	// matches, i.e. `0 0 13 * FRI` fires on every 13th and on every Friday.
+	// `?` is a synonym of `*`. This is the default.
+	DialectVixie Dialect = iota
+	// DialectQuartz follows the Quartz scheduler: when both the day-of-month
+	// and day-of-week fields are restricted, a day matches if both fields
+	// match, i.e. `0 0 13 * FRI` fires on Friday the 13th only. `?` stands
+	// for "no specific value", it is allowed only as the sole directive of
+	// either the day-of-month field or the day-of-week field, not both.
+	//
+	// As with Quartz, 6 fields are the second field and 5 fields, e.g.
+	// `0 15 10 ? * 6L`, and days of week are numbered from 1 (Sunday) to 7
+	// (Saturday), e.g. `0 0 12 ? * 2-6` fires on weekdays. 5 and 7 fields
+	// are laid out as with the Vixie dialect.
+	DialectQuartz
+)
+
+// WithDialect selects the flavor of cron the cron expression is written in.
+//
+// The dialect is not part of the canonical form returned by String(), hence
+// the canonical form must be parsed with the same dialect to yield an
+// equivalent Expression, and an Expression parsed with the Quartz dialect
+// cannot be marshaled, see ErrNotEncodable.
+func WithDialect(dialect Dialect) Option {
+	return func(opts *parseOptions) {
+		opts.dialect = dialect
+	}
+}
+
+// quartzWeekdays returns a copy of the day-of-week descriptor `desc` which
+// numbers days of week from 1 (Sunday) to 7 (Saturday), as Quartz does.
+func quartzWeekdays(desc fieldDescriptor) fieldDescriptor {
+	atoi := desc.atoi
+	desc.valuePattern = strings.Replace(desc.valuePattern, `0?[0-7]`, `0?[1-7]`, 1)
+	desc.atoi = func(s string) int {
+		if v, err := strconv.Atoi(s); err == nil {
+			return v - 1
+		}
+		return atoi(s)
+	}
+	return desc
+}
+
+// daysIntersect returns whether a day must match both the day-of-month and
+// the day-of-week fields when both are restricted.
+func (expr *Expression) daysIntersect() bool {
+	return expr.dialect == DialectQuartz
+}
+
+/******************************************************************************/
+
+// checkQuestionMarks enforces the Quartz placement rules of `?` over the
+// fields of a cron expression, `descs` being their respective descriptors.
+func checkQuestionMarks(fields []cronField, descs []fieldDescriptor, cronLine string) error {
+	var dayField *cronField
+	for i := range fields {
+		field := &fields[i]
+		if strings.IndexByte(field.s, '?') < 0 {
+			continue
+		}
+		desc := descs[i]
+		isDayField := desc.name == domDescriptor.name || desc.name == dowDescriptor.name
+		if !isDayField || field.s != "?" || dayField != nil {
+			err := &ParseError{
+				Kind:      ErrorQuestionMark,
+				Input:     field.s,
+				Field:     desc.name,
+				Begin:     0,
+				End:       len(field.s),
+				Directive: field.s,
+			}
+			return field.relocate(err, cronLine)
+		}
+		dayField = field
+	}
+	return nil
+}
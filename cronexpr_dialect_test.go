@@ -0,0 +1,177 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_dialect_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+type dialecttest struct {
+	expr   string
+	vixie  []string
+	quartz []string
+}
+
+// Same expressions, side by side, from 2024-01-01 00:00:00
+var dialecttests = []dialecttest{
+	{
+		"0 0 13 * FRI",
+		[]string{"Fri 2024-01-05", "Fri 2024-01-12", "Sat 2024-01-13", "Fri 2024-01-19"},
+		[]string{"Fri 2024-09-13", "Fri 2024-12-13", "Fri 2025-06-13", "Fri 2026-02-13"},
+	},
+	{
+		"0 0 1-7 * MON",
+		[]string{"Mon 2024-01-01", "Tue 2024-01-02", "Wed 2024-01-03", "Thu 2024-01-04"},
+		[]string{"Mon 2024-01-01", "Mon 2024-02-05", "Mon 2024-03-04", "Mon 2024-04-01"},
+	},
+	{
+		"0 0 L * FRIL",
+		[]string{"Fri 2024-01-26", "Wed 2024-01-31", "Fri 2024-02-23", "Thu 2024-02-29"},
+		[]string{"Fri 2024-05-31", "Fri 2025-01-31", "Fri 2025-02-28", "Fri 2025-10-31"},
+	},
+	{
+		"0 0 ? * FRI",
+		[]string{"Fri 2024-01-05", "Fri 2024-01-12", "Fri 2024-01-19", "Fri 2024-01-26"},
+		[]string{"Fri 2024-01-05", "Fri 2024-01-12", "Fri 2024-01-19", "Fri 2024-01-26"},
+	},
+	{
+		"0 0 13 * ?",
+		[]string{"Sat 2024-01-13", "Tue 2024-02-13", "Wed 2024-03-13", "Sat 2024-04-13"},
+		[]string{"Sat 2024-01-13", "Tue 2024-02-13", "Wed 2024-03-13", "Sat 2024-04-13"},
+	},
+	{
+		"0 0 * * FRI",
+		[]string{"Fri 2024-01-05", "Fri 2024-01-12", "Fri 2024-01-19", "Fri 2024-01-26"},
+		[]string{"Fri 2024-01-05", "Fri 2024-01-12", "Fri 2024-01-19", "Fri 2024-01-26"},
+	},
+}
+
+func TestDialect(t *testing.T) {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+	for _, test := range dialecttests {
+		for _, dialect := range []Dialect{DialectVixie, DialectQuartz} {
+			expected := test.vixie
+			if dialect == DialectQuartz {
+				expected = test.quartz
+			}
+			expr, err := ParseWithOptions(test.expr, WithDialect(dialect))
+			if err != nil {
+				t.Errorf(`ParseWithOptions("%s", %d) returned "%s"`, test.expr, dialect, err)
+				continue
+			}
+			result := expr.NextN(from, uint(len(expected)))
+			if len(result) != len(expected) {
+				t.Errorf(`("%s", %d).NextN() returned %d time values, expected %d`, test.expr, dialect, len(result), len(expected))
+				continue
+			}
+			for i := range result {
+				if s := result[i].Format("Mon 2006-01-02"); s != expected[i] {
+					t.Errorf(`("%s", %d).NextN()[%d] = "%s", expected "%s"`, test.expr, dialect, i, s, expected[i])
+				}
+				if !expr.Matches(result[i]) {
+					t.Errorf(`("%s", %d).Matches("%s") returned 'false', expected 'true'`, test.expr, dialect, expected[i])
+				}
+				if i > 0 && !expr.Prev(result[i]).Equal(result[i-1]) {
+					t.Errorf(`("%s", %d).Prev("%s") = "%s", expected "%s"`, test.expr, dialect, expected[i], expr.Prev(result[i]), expected[i-1])
+				}
+			}
+			// A day between two results must not match
+			if dialect == DialectQuartz && len(result) > 1 {
+				if between := result[0].AddDate(0, 0, 1); between.Before(result[1]) && expr.Matches(between) {
+					t.Errorf(`("%s", %d).Matches("%s") returned 'true', expected 'false'`, test.expr, dialect, between)
+				}
+			}
+		}
+	}
+}
+
+// Expressions from the Quartz documentation, which start with the second field
+// and number days of week from 1 (Sunday) to 7 (Saturday)
+func TestDialectQuartzLayout(t *testing.T) {
+	tests := []struct {
+		expr      string
+		canonical string
+		next      []string
+	}{
+		{"0 15 10 ? * 6L", "0 15 10 * * 6L *", []string{"Fri 2024-01-26 10:15:00", "Fri 2024-02-23 10:15:00"}},
+		{"0 0 12 ? * MON", "0 0 12 * * 2 *", []string{"Mon 2024-01-01 12:00:00", "Mon 2024-01-08 12:00:00"}},
+		{"0 0 12 ? * 2", "0 0 12 * * 2 *", []string{"Mon 2024-01-01 12:00:00", "Mon 2024-01-08 12:00:00"}},
+		{"0 0 12 ? * 1,7", "0 0 12 * * 1,7 *", []string{"Sat 2024-01-06 12:00:00", "Sun 2024-01-07 12:00:00"}},
+		{"0 0 12 ? * 2-6", "0 0 12 * * 2-6 *", []string{"Mon 2024-01-01 12:00:00", "Tue 2024-01-02 12:00:00"}},
+		{"30 0 12 ? * 6#3", "30 0 12 * * 6#3 *", []string{"Fri 2024-01-19 12:00:30", "Fri 2024-02-16 12:00:30"}},
+		{"0 0 12 ? * 7-1", "0 0 12 * * 1,7 *", []string{"Sat 2024-01-06 12:00:00", "Sun 2024-01-07 12:00:00"}},
+		{"0 0 12 * * ? 2025", "0 0 12 * * * 2025", []string{"Wed 2025-01-01 12:00:00", "Thu 2025-01-02 12:00:00"}},
+	}
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, test := range tests {
+		expr, err := ParseWithOptions(test.expr, WithDialect(DialectQuartz))
+		if err != nil {
+			t.Errorf(`ParseWithOptions("%s", DialectQuartz) returned "%s"`, test.expr, err)
+			continue
+		}
+		if s := expr.String(); s != test.canonical {
+			t.Errorf(`("%s", DialectQuartz).String() = "%s", expected "%s"`, test.expr, s, test.canonical)
+		}
+		if again, err := ParseWithOptions(expr.String(), WithDialect(DialectQuartz)); err != nil || again.String() != test.canonical {
+			t.Errorf(`("%s", DialectQuartz).String() does not round-trip: %v`, test.expr, err)
+		}
+		var actual []string
+		for _, next := range expr.NextN(from, uint(len(test.next))) {
+			actual = append(actual, next.Format("Mon 2006-01-02 15:04:05"))
+		}
+		if !equalStrings(actual, test.next) {
+			t.Errorf(`("%s", DialectQuartz).NextN() = %q, expected %q`, test.expr, actual, test.next)
+		}
+	}
+
+	// 0 is not a day of week
+	_, err := ParseWithOptions("0 0 12 ? * 0", WithDialect(DialectQuartz))
+	if perr, ok := err.(*ParseError); !ok || perr.Kind != ErrorSyntax || perr.Field != "day-of-week" {
+		t.Errorf(`ParseWithOptions("0 0 12 ? * 0", DialectQuartz) returned "%v", expected a syntax error`, err)
+	}
+}
+
+/******************************************************************************/
+
+var questionMarkTests = []parseErrorTest{
+	{"0 ? * * *", ErrorQuestionMark, "hour", "?", 2},
+	{"0 0 ? * ?", ErrorQuestionMark, "day-of-week", "?", 8},
+	{"0 0 ?,1 * MON", ErrorQuestionMark, "day-of-month", "?,1", 4},
+	{"0 0 0 1 * ? ?", ErrorQuestionMark, "year", "?", 12},
+	{"CRON_TZ=UTC 0 0 1 ? *", ErrorQuestionMark, "month", "?", 18},
+}
+
+func TestDialectQuestionMark(t *testing.T) {
+	for _, test := range questionMarkTests {
+		// Vixie dialect: `?` is a synonym of `*`
+		if _, err := ParseWithOptions(test.expr); err != nil {
+			t.Errorf(`ParseWithOptions("%s") returned "%s", expected no error`, test.expr, err)
+		}
+		_, err := ParseWithOptions(test.expr, WithDialect(DialectQuartz))
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`ParseWithOptions("%s", DialectQuartz) returned "%v", expected a *ParseError`, test.expr, err)
+			continue
+		}
+		if perr.Kind != test.kind || perr.Field != test.field || perr.Directive != test.directive || perr.Begin != test.begin {
+			t.Errorf(`ParseWithOptions("%s", DialectQuartz) returned %v %q %q @%d, expected %v %q %q @%d`,
+				test.expr, perr.Kind, perr.Field, perr.Directive, perr.Begin,
+				test.kind, test.field, test.directive, test.begin)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_dst.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// DSTGapPolicy tells what to do with matching local times which do not exist
+// because clocks are set forward, e.g. 02:30 on the day daylight-saving time
+// starts in Europe.
+type DSTGapPolicy int
+
+const (
+	// DSTGapShift shifts nonexistent local times forward by the length of
+	// the gap, i.e. 02:30 becomes 03:30 when clocks are set forward from
+	// 02:00 to 03:00. This is the default.
+	DSTGapShift DSTGapPolicy = iota
+	// DSTGapSkip ignores nonexistent local times.
+	DSTGapSkip
+	// DSTGapTransition fires once at the instant clocks are set forward if
+	// any matching local time falls in the gap, i.e. 02:30 becomes 03:00.
+	DSTGapTransition
+)
+
+// DSTOverlapPolicy tells what to do with matching local times which occur
+// twice because clocks are set back, e.g. 02:30 on the day daylight-saving
+// time ends in Europe.
+type DSTOverlapPolicy int
+
+const (
+	// DSTOverlapAny fires at only one of the two instants, whichever
+	// `time.Date` picks. This is the default.
+	DSTOverlapAny DSTOverlapPolicy = iota
+	// DSTOverlapOnce fires at the earlier of the two instants only.
+	DSTOverlapOnce
+	// DSTOverlapTwice fires at both instants.
+	DSTOverlapTwice
+)
+
+/******************************************************************************/
+
+// WithDSTPolicy selects how Next, NextN, Prev, PrevN and Matches deal with
+// daylight-saving time transitions in the `time.Location` of the time
+// instants they are given.
+func WithDSTPolicy(gap DSTGapPolicy, overlap DSTOverlapPolicy) Option {
+	return func(opts *parseOptions) {
+		opts.dstGap, opts.dstOverlap = gap, overlap
+		opts.vixieDST = false
+	}
+}
+
+// WithVixieDST selects the daylight-saving time policy documented by Vixie
+// cron: an expression firing at a fixed time, i.e. with neither the minute
+// nor the hour field starting with `*`, fires at the transition instant when
+// its local time is skipped and fires only once when its local time is
+// repeated, while other expressions fire according to the actual local time,
+// i.e. skipped local times are ignored and repeated local times fire twice.
+func WithVixieDST() Option {
+	return func(opts *parseOptions) {
+		opts.vixieDST = true
+	}
+}
+
+func vixieDSTPolicy(minuteField, hourField string) (DSTGapPolicy, DSTOverlapPolicy) {
+	if strings.HasPrefix(minuteField, "*") || strings.HasPrefix(hourField, "*") {
+		return DSTGapSkip, DSTOverlapTwice
+	}
+	return DSTGapTransition, DSTOverlapOnce
+}
+
+func (expr *Expression) dstAware() bool {
+	return expr.dstGap != DSTGapShift || expr.dstOverlap != DSTOverlapAny
+}
+
+// byZonePeriod tells whether `t` must be evaluated one zone period at a
+// time, i.e. whether a daylight-saving time policy was selected or the UTC
+// offset of the location of `t` may change. Locations with a fixed offset,
+// e.g. UTC, need not be.
+func (expr *Expression) byZonePeriod(t time.Time) bool {
+	if expr.dstAware() {
+		return true
+	}
+	start, end := t.ZoneBounds()
+	return !start.IsZero() || !end.IsZero()
+}
+
+/******************************************************************************/
+
+// When a daylight-saving time policy is in effect, the cron expression is
+// evaluated against local wall clock time, represented as UTC time values so
+// that no normalization ever occurs, then local times are mapped back to
+// time instants one zone period at a time, a zone period being a time range
+// during which the UTC offset of the location does not change.
+
+func wallClock(t time.Time, offset int) time.Time {
+	return time.Unix(t.Unix()+int64(offset), int64(t.Nanosecond())).UTC()
+}
+
+func fromWallClock(w time.Time, offset int, loc *time.Location) time.Time {
+	return time.Unix(w.Unix()-int64(offset), int64(w.Nanosecond())).In(loc)
+}
+
+// zoneBounds is like time.Time.ZoneBounds, except that the zone period
+// returned always contains `t`. Past the last transition of the tz database,
+// the former may return the zone period which ends at `t`, or even before
+// `t`, when `t` is near the end of a year.
+func zoneBounds(t time.Time) (time.Time, time.Time) {
+	start, end := t.ZoneBounds()
+	if end.IsZero() || t.Before(end) {
+		return start, end
+	}
+	// The zone period of `t` starts at `end`, it ends where the zone period
+	// found a little later starts, unless the latter starts at `end` too
+	start = end
+	nextStart, nextEnd := end.Add(48 * time.Hour).ZoneBounds()
+	if nextStart.After(start) {
+		return start, nextStart
+	}
+	return start, nextEnd
+}
+
+// gapBefore returns the UTC offset in effect before the zone period starting
+// at `start`, and whether clocks were set forward at `start`.
+func gapBefore(start time.Time, offset int) (int, bool) {
+	if start.IsZero() {
+		return offset, false
+	}
+	_, prevOffset := start.Add(-time.Nanosecond).Zone()
+	return prevOffset, prevOffset < offset
+}
+
+/******************************************************************************/
+
+func (expr *Expression) nextDST(fromTime time.Time) time.Time {
+	loc := fromTime.Location()
+	at := fromTime
+	_, offset := at.Zone()
+	wallFrom := wallClock(fromTime, offset)
+	var shifted time.Time
+
+	for {
+		_, offset = at.Zone()
+		start, end := zoneBounds(at)
+
+		// Nonexistent local times shifted forward land at the start of
+		// the zone period
+		if expr.dstGap == DSTGapShift {
+			if prevOffset, gap := gapBefore(start, offset); gap {
+				lower := start.Add(-time.Nanosecond)
+				if fromTime.After(lower) {
+					lower = fromTime
+				}
+				w := expr.next(wallClock(lower, prevOffset))
+				if w.IsZero() == false && w.Before(wallClock(start, offset)) {
+					shifted = fromWallClock(w, prevOffset, loc)
+				}
+			}
+		}
+
+		w := expr.next(wallFrom)
+		if w.IsZero() {
+			return shifted
+		}
+		t := fromWallClock(w, offset, loc)
+		if end.IsZero() || t.Before(end) {
+			if shifted.IsZero() == false && shifted.Before(t) {
+				return shifted
+			}
+			if expr.acceptOverlap(w, t) {
+				return t
+			}
+			wallFrom = w
+			continue
+		}
+		if shifted.IsZero() == false {
+			return shifted
+		}
+
+		// `w` lies beyond the current zone period
+		_, nextOffset := end.Zone()
+		if nextOffset > offset && fromWallClock(w, nextOffset, loc).Before(end) {
+			// `w` is a nonexistent local time
+			if expr.dstGap == DSTGapTransition {
+				return end
+			}
+		}
+		at = end
+		wallFrom = wallClock(end, nextOffset).Add(-time.Nanosecond)
+	}
+}
+
+/******************************************************************************/
+
+func (expr *Expression) prevDST(fromTime time.Time) time.Time {
+	loc := fromTime.Location()
+	at := fromTime
+	_, offset := at.Zone()
+	wallTo := wallClock(fromTime, offset)
+	var shifted time.Time
+
+	for {
+		_, offset = at.Zone()
+		start, _ := zoneBounds(at)
+		prevOffset, gap := gapBefore(start, offset)
+
+		// Nonexistent local times shifted forward land at the start of
+		// the zone period
+		if expr.dstGap == DSTGapShift && gap {
+			upper := wallClock(start, offset)
+			if bound := wallClock(fromTime, prevOffset); bound.Before(upper) {
+				upper = bound
+			}
+			w := expr.prev(upper)
+			if w.IsZero() == false && !w.Before(wallClock(start, prevOffset)) {
+				shifted = fromWallClock(w, prevOffset, loc)
+			}
+		}
+
+		w := expr.prev(wallTo)
+		if w.IsZero() {
+			return shifted
+		}
+		t := fromWallClock(w, offset, loc)
+		if start.IsZero() || !t.Before(start) {
+			if expr.acceptOverlap(w, t) {
+				if shifted.After(t) {
+					return shifted
+				}
+				return t
+			}
+			wallTo = w
+			continue
+		}
+		if shifted.IsZero() == false {
+			return shifted
+		}
+
+		// `w` lies before the current zone period
+		if gap && !fromWallClock(w, prevOffset, loc).Before(start) {
+			// `w` is a nonexistent local time
+			if expr.dstGap == DSTGapTransition && start.Before(fromTime) {
+				return start
+			}
+		}
+		at = start.Add(-time.Nanosecond)
+		wallTo = wallClock(start, prevOffset)
+	}
+}
+
+/******************************************************************************/
+
+// acceptOverlap returns whether time instant `t`, which local time is `w`,
+// must be kept as per the daylight-saving time overlap policy.
+func (expr *Expression) acceptOverlap(w, t time.Time) bool {
+	switch expr.dstOverlap {
+	case DSTOverlapTwice:
+		return true
+	case DSTOverlapAny:
+		return t.Equal(time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), t.Location()))
+	}
+	// Reject `t` if the same local time already occurred in the previous
+	// zone period
+	start, _ := zoneBounds(t)
+	if start.IsZero() {
+		return true
+	}
+	before := start.Add(-time.Nanosecond)
+	_, offset := t.Zone()
+	_, prevOffset := before.Zone()
+	if prevOffset <= offset {
+		return true
+	}
+	earlier := fromWallClock(w, prevOffset, t.Location())
+	prevStart, _ := zoneBounds(before)
+	return !earlier.Before(start) || (prevStart.IsZero() == false && earlier.Before(prevStart))
+}
@@ -0,0 +1,283 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_dst_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+/******************************************************************************/
+
+type dsttest struct {
+	expr    string
+	zone    string
+	options []Option
+	from    string
+	next    []string
+}
+
+var (
+	dstShift      = WithDSTPolicy(DSTGapShift, DSTOverlapOnce)
+	dstSkip       = WithDSTPolicy(DSTGapSkip, DSTOverlapOnce)
+	dstTransition = WithDSTPolicy(DSTGapTransition, DSTOverlapOnce)
+	dstTwice      = WithDSTPolicy(DSTGapSkip, DSTOverlapTwice)
+	dstVixie      = WithVixieDST()
+)
+
+var dsttests = []dsttest{
+	// Europe/Berlin: 2024-03-31 02:00 -> 03:00, 2024-10-27 03:00 -> 02:00
+	{"30 2 * * *", "Europe/Berlin", nil, "2024-03-30T12:00:00+01:00", []string{
+		"2024-03-31T03:30:00+02:00",
+		"2024-04-01T02:30:00+02:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstShift}, "2024-03-30T12:00:00+01:00", []string{
+		"2024-03-31T03:30:00+02:00",
+		"2024-04-01T02:30:00+02:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstSkip}, "2024-03-30T12:00:00+01:00", []string{
+		"2024-04-01T02:30:00+02:00",
+		"2024-04-02T02:30:00+02:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstTransition}, "2024-03-30T12:00:00+01:00", []string{
+		"2024-03-31T03:00:00+02:00",
+		"2024-04-01T02:30:00+02:00",
+	}},
+	{"15,45 2 * * *", "Europe/Berlin", []Option{dstTransition}, "2024-03-30T12:00:00+01:00", []string{
+		"2024-03-31T03:00:00+02:00",
+		"2024-04-01T02:15:00+02:00",
+		"2024-04-01T02:45:00+02:00",
+	}},
+	{"0 3 * * *", "Europe/Berlin", []Option{dstTransition}, "2024-03-30T12:00:00+01:00", []string{
+		"2024-03-31T03:00:00+02:00",
+		"2024-04-01T03:00:00+02:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstVixie}, "2024-03-30T12:00:00+01:00", []string{
+		"2024-03-31T03:00:00+02:00",
+		"2024-04-01T02:30:00+02:00",
+	}},
+	{"*/20 * * * *", "Europe/Berlin", []Option{dstVixie}, "2024-03-31T01:30:00+01:00", []string{
+		"2024-03-31T01:40:00+01:00",
+		"2024-03-31T03:00:00+02:00",
+		"2024-03-31T03:20:00+02:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstSkip}, "2024-10-26T12:00:00+02:00", []string{
+		"2024-10-27T02:30:00+02:00",
+		"2024-10-28T02:30:00+01:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstTwice}, "2024-10-26T12:00:00+02:00", []string{
+		"2024-10-27T02:30:00+02:00",
+		"2024-10-27T02:30:00+01:00",
+		"2024-10-28T02:30:00+01:00",
+	}},
+	{"30 2 * * *", "Europe/Berlin", []Option{dstVixie}, "2024-10-27T02:40:00+02:00", []string{
+		"2024-10-28T02:30:00+01:00",
+	}},
+	{"*/20 * * * *", "Europe/Berlin", []Option{dstVixie}, "2024-10-27T02:30:00+02:00", []string{
+		"2024-10-27T02:40:00+02:00",
+		"2024-10-27T02:00:00+01:00",
+		"2024-10-27T02:20:00+01:00",
+	}},
+	{"*/20 * * * *", "Europe/Berlin", []Option{dstSkip}, "2024-10-27T02:30:00+01:00", []string{
+		"2024-10-27T03:00:00+01:00",
+	}},
+
+	// America/New_York: 2024-03-10 02:00 -> 03:00, 2024-11-03 02:00 -> 01:00
+	{"30 2 * * *", "America/New_York", nil, "2026-03-08T01:45:00-05:00", []string{
+		"2026-03-08T03:30:00-04:00",
+		"2026-03-09T02:30:00-04:00",
+	}},
+	{"15,45 2 * * *", "America/New_York", []Option{dstSkip}, "2024-03-10T00:00:00-05:00", []string{
+		"2024-03-11T02:15:00-04:00",
+		"2024-03-11T02:45:00-04:00",
+	}},
+	{"15,45 2 * * *", "America/New_York", []Option{dstShift}, "2024-03-10T00:00:00-05:00", []string{
+		"2024-03-10T03:15:00-04:00",
+		"2024-03-10T03:45:00-04:00",
+		"2024-03-11T02:15:00-04:00",
+	}},
+	{"30 1 * * *", "America/New_York", []Option{dstTwice}, "2024-11-03T00:00:00-04:00", []string{
+		"2024-11-03T01:30:00-04:00",
+		"2024-11-03T01:30:00-05:00",
+		"2024-11-04T01:30:00-05:00",
+	}},
+	{"30 1 * * *", "America/New_York", []Option{dstTransition}, "2024-11-03T00:00:00-04:00", []string{
+		"2024-11-03T01:30:00-04:00",
+		"2024-11-04T01:30:00-05:00",
+	}},
+	{"30 1 * * *", "America/New_York", []Option{dstTransition}, "2024-11-03T01:00:00-05:00", []string{
+		"2024-11-04T01:30:00-05:00",
+	}},
+
+	// Australia/Lord_Howe, 30 minute shifts: 2024-04-07 02:00 -> 01:30,
+	// 2024-10-06 02:00 -> 02:30
+	{"15,40 2 * * *", "Australia/Lord_Howe", []Option{dstShift}, "2024-10-06T00:00:00+10:30", []string{
+		"2024-10-06T02:40:00+11:00",
+		"2024-10-06T02:45:00+11:00",
+		"2024-10-07T02:15:00+11:00",
+	}},
+	{"15,40 2 * * *", "Australia/Lord_Howe", []Option{dstTransition}, "2024-10-06T00:00:00+10:30", []string{
+		"2024-10-06T02:30:00+11:00",
+		"2024-10-06T02:40:00+11:00",
+		"2024-10-07T02:15:00+11:00",
+	}},
+	{"45 1 * * *", "Australia/Lord_Howe", []Option{dstTwice}, "2024-04-07T00:00:00+11:00", []string{
+		"2024-04-07T01:45:00+11:00",
+		"2024-04-07T01:45:00+10:30",
+		"2024-04-08T01:45:00+10:30",
+	}},
+	{"45 1 * * *", "Australia/Lord_Howe", []Option{dstSkip}, "2024-04-07T01:50:00+11:00", []string{
+		"2024-04-08T01:45:00+10:30",
+	}},
+
+	// Australia/Sydney, southern hemisphere: 2024-10-06 02:00 -> 03:00
+	{"0 2 * * *", "Australia/Sydney", []Option{dstVixie}, "2024-10-05T12:00:00+10:00", []string{
+		"2024-10-06T03:00:00+11:00",
+		"2024-10-07T02:00:00+11:00",
+	}},
+
+	// America/Havana, transitions at midnight: 2024-03-10 00:00 -> 01:00,
+	// 2024-11-03 01:00 -> 00:00
+	{"30 0 * * *", "America/Havana", []Option{dstSkip}, "2024-03-09T12:00:00-05:00", []string{
+		"2024-03-11T00:30:00-04:00",
+	}},
+	{"30 0 * * *", "America/Havana", []Option{dstTransition}, "2024-03-09T12:00:00-05:00", []string{
+		"2024-03-10T01:00:00-04:00",
+		"2024-03-11T00:30:00-04:00",
+	}},
+	{"30 0 10 3 *", "America/Havana", []Option{dstShift}, "2024-03-09T12:00:00-05:00", []string{
+		"2024-03-10T01:30:00-04:00",
+		"2025-03-10T00:30:00-04:00",
+	}},
+	{"30 0 * * *", "America/Havana", []Option{dstTwice}, "2024-11-02T12:00:00-04:00", []string{
+		"2024-11-03T00:30:00-04:00",
+		"2024-11-03T00:30:00-05:00",
+		"2024-11-04T00:30:00-05:00",
+	}},
+
+	// No transition at all
+	{"30 2 * * *", "UTC", []Option{dstTransition}, "2024-03-30T12:00:00Z", []string{
+		"2024-03-31T02:30:00Z",
+		"2024-04-01T02:30:00Z",
+	}},
+}
+
+func TestDST(t *testing.T) {
+	for _, test := range dsttests {
+		loc, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expr, err := ParseWithOptions(test.expr, test.options...)
+		if err != nil {
+			t.Errorf(`ParseWithOptions("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		from, _ := time.Parse(time.RFC3339, test.from)
+		from = from.In(loc)
+		result := expr.NextN(from, uint(len(test.next)))
+		if len(result) != len(test.next) {
+			t.Errorf(`("%s").NextN("%s" %s) returned %d time values, expected %d`, test.expr, test.from, test.zone, len(result), len(test.next))
+			continue
+		}
+		for i := range result {
+			if s := result[i].Format(time.RFC3339); s != test.next[i] {
+				t.Errorf(`("%s").NextN("%s" %s)[%d] = "%s", expected "%s"`, test.expr, test.from, test.zone, i, s, test.next[i])
+			}
+			if result[i].Location() != loc {
+				t.Errorf(`("%s").NextN("%s" %s)[%d] is not in %s`, test.expr, test.from, test.zone, i, test.zone)
+			}
+			if !expr.Matches(result[i]) {
+				t.Errorf(`("%s").Matches("%s" %s) returned 'false', expected 'true'`, test.expr, test.next[i], test.zone)
+			}
+			// Walking backward must yield the same time instants
+			if i > 0 {
+				if prev := expr.Prev(result[i]); !prev.Equal(result[i-1]) {
+					t.Errorf(`("%s").Prev("%s" %s) = "%s", expected "%s"`, test.expr, test.next[i], test.zone, prev.Format(time.RFC3339), test.next[i-1])
+				}
+			}
+		}
+	}
+}
+
+// Every local time of a busy expression evaluated across a whole year of
+// transitions must come out in strictly ascending order, and Prev must retrace
+// the exact same steps.
+func TestDSTMonotonic(t *testing.T) {
+	zones := []string{"Europe/Berlin", "America/New_York", "Australia/Lord_Howe", "America/Havana"}
+	policies := []Option{dstShift, dstSkip, dstTransition, dstTwice, dstVixie}
+	for _, zone := range zones {
+		loc, _ := time.LoadLocation(zone)
+		for _, policy := range policies {
+			expr, _ := ParseWithOptions("*/15 0-3 * * *", policy)
+			from := time.Date(2024, time.January, 1, 0, 0, 0, 0, loc)
+			to := time.Date(2025, time.January, 1, 0, 0, 0, 0, loc)
+			var times []time.Time
+			for next := expr.Next(from); next.Before(to); next = expr.Next(next) {
+				if len(times) > 0 && !next.After(times[len(times)-1]) {
+					t.Fatalf(`%s: Next("%s") = "%s"`, zone, times[len(times)-1], next)
+				}
+				times = append(times, next)
+			}
+			for i := len(times) - 1; i > 0; i-- {
+				if prev := expr.Prev(times[i]); !prev.Equal(times[i-1]) {
+					t.Fatalf(`%s: Prev("%s") = "%s", expected "%s"`, zone, times[i], prev, times[i-1])
+				}
+			}
+		}
+	}
+}
+
+// Past the last transition of the tz database, i.e. beyond 2037, transitions
+// are derived from rules, and zone periods must still be walked through.
+func TestDSTBeyond2037(t *testing.T) {
+	tests := []struct {
+		expr   string
+		option Option
+		from   string
+		next   string
+	}{
+		{"0 0 1 1 *", dstVixie, "2040-06-01T00:00:00+02:00", "2041-01-01T00:00:00+01:00"},
+		{"0 0 1 1 *", dstSkip, "2040-06-01T00:00:00+02:00", "2041-01-01T00:00:00+01:00"},
+		{"0 0 29 2 *", dstSkip, "2040-06-01T00:00:00+02:00", "2044-02-29T00:00:00+01:00"},
+		{"30 2 * * *", dstTransition, "2045-03-25T12:00:00+01:00", "2045-03-26T03:00:00+02:00"},
+	}
+	loc, _ := time.LoadLocation("Europe/Berlin")
+	for _, test := range tests {
+		expr, _ := ParseWithOptions(test.expr, test.option)
+		from, _ := time.Parse(time.RFC3339, test.from)
+		from = from.In(loc)
+		done := make(chan [3]time.Time, 1)
+		go func() {
+			next := expr.Next(from)
+			matches := time.Time{}
+			if expr.Matches(next) {
+				matches = next
+			}
+			done <- [3]time.Time{next, matches, expr.Prev(next.Add(time.Hour))}
+		}()
+		select {
+		case times := <-done:
+			for i, what := range []string{"Next", "Matches", "Prev"} {
+				if s := times[i].Format(time.RFC3339); s != test.next {
+					t.Errorf(`("%s") %s from "%s" = "%s", expected "%s"`, test.expr, what, test.from, s, test.next)
+				}
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf(`("%s").Next("%s") did not return within 2 seconds`, test.expr, test.from)
+		}
+	}
+}
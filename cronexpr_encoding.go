@@ -0,0 +1,102 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_encoding.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+/******************************************************************************/
+
+// isZero returns whether `expr` is the zero Expression, i.e. it did not come
+// out of Parse.
+func (expr *Expression) isZero() bool {
+	return expr.secondList == nil && expr.every == 0 && expr.members == nil
+}
+
+// ErrNotEncodable is returned when marshaling an Expression whose canonical
+// form would be parsed back into a different schedule, i.e. an Expression
+// parsed with the Quartz dialect or with a daylight-saving time policy other
+// than the default one, see String().
+var ErrNotEncodable = errors.New("cronexpr: dialect and daylight-saving time policy cannot be encoded")
+
+// encodable returns whether parsing the canonical form of `expr` with Parse
+// yields an Expression firing at the same time instants.
+func (expr *Expression) encodable() bool {
+	for _, member := range expr.members {
+		if !member.(*Expression).encodable() {
+			return false
+		}
+	}
+	return expr.dialect == DialectVixie && !expr.dstAware()
+}
+
+/******************************************************************************/
+
+// MarshalText implements the encoding.TextMarshaler interface. The canonical
+// form of the cron expression is returned, see String(). The zero Expression
+// is marshaled as an empty string. ErrNotEncodable is returned if the
+// canonical form does not round-trip through UnmarshalText.
+func (expr Expression) MarshalText() ([]byte, error) {
+	if expr.isZero() {
+		return []byte{}, nil
+	}
+	if !expr.encodable() {
+		return nil, ErrNotEncodable
+	}
+	return []byte(expr.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text is
+// parsed with Parse, and the error returned by Parse, if any, is returned
+// as is. An empty text yields the zero Expression.
+func (expr *Expression) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*expr = Expression{}
+		return nil
+	}
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*expr = *parsed
+	return nil
+}
+
+/******************************************************************************/
+
+// MarshalJSON implements the json.Marshaler interface. The cron expression is
+// encoded as a JSON string holding its canonical form, see MarshalText.
+func (expr Expression) MarshalJSON() ([]byte, error) {
+	text, err := expr.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A JSON string is
+// expected, which is parsed with Parse. A JSON null leaves `expr` unchanged.
+func (expr *Expression) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return expr.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,155 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_encoding_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+type jobConfig struct {
+	Name     string
+	Schedule Expression
+	Backup   *Expression `json:",omitempty"`
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	for _, test := range stringTests {
+		var expr Expression
+		if err := expr.UnmarshalText([]byte(test.expr)); err != nil {
+			t.Errorf(`UnmarshalText("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		text, err := expr.MarshalText()
+		if err != nil {
+			t.Errorf(`("%s").MarshalText() returned "%s"`, test.expr, err)
+			continue
+		}
+		if string(text) != test.canonical {
+			t.Errorf(`("%s").MarshalText() = "%s", expected "%s"`, test.expr, text, test.canonical)
+		}
+	}
+}
+
+func TestEncodeOptions(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	// Options which the canonical form records
+	seed := "backup"
+	tests := []struct {
+		expr    string
+		options []Option
+	}{
+		{"0 0 13 * FRI", nil},
+		{"0 H 9 * * 1-5", []Option{WithHashSeed(seed)}},
+		{"0 9 * * lun-ven", []Option{WithLocale(French)}},
+		{"0 0 1 * * 2030", []Option{WithStrict()}},
+	}
+	for _, test := range tests {
+		expr, err := ParseWithOptions(test.expr, test.options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(expr)
+		if err != nil {
+			t.Errorf(`json.Marshal("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		var decoded Expression
+		if err = json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf(`json.Unmarshal(%s) returned "%s"`, data, err)
+			continue
+		}
+		actual, expected := formatTimes(decoded.NextN(from, 5)), formatTimes(expr.NextN(from, 5))
+		if !equalStrings(actual, expected) {
+			t.Errorf(`round trip of "%s" fires at %v, expected %v`, test.expr, actual, expected)
+		}
+	}
+
+	// Options which it does not
+	for _, options := range [][]Option{
+		{WithDialect(DialectQuartz)},
+		{WithDSTPolicy(DSTGapSkip, DSTOverlapTwice)},
+		{WithVixieDST()},
+	} {
+		expr, _ := ParseWithOptions("0 0 13 * FRI", options...)
+		if _, err := json.Marshal(expr); err == nil {
+			t.Errorf(`json.Marshal() returned no error`)
+		}
+		if _, err := expr.MarshalText(); err != ErrNotEncodable {
+			t.Errorf(`MarshalText() returned "%v", expected ErrNotEncodable`, err)
+		}
+	}
+	union, _ := ParseWithOptions("0 0 13 * FRI | @daily", WithDialect(DialectQuartz))
+	if _, err := union.MarshalText(); err != ErrNotEncodable {
+		t.Errorf(`MarshalText() returned "%v", expected ErrNotEncodable`, err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var config jobConfig
+	err := json.Unmarshal([]byte(`{"Name":"backup","Schedule":"@daily","Backup":"*/5 * * * *"}`), &config)
+	if err != nil {
+		t.Fatalf(`json.Unmarshal() returned "%s"`, err)
+	}
+	from, _ := time.Parse("2006-01-02 15:04:05", "2013-09-02 08:44:32")
+	if next := config.Schedule.Next(from).Format("2006-01-02 15:04:05"); next != "2013-09-03 00:00:00" {
+		t.Errorf(`Schedule.Next("2013-09-02 08:44:32") = "%s", expected "2013-09-03 00:00:00"`, next)
+	}
+	if next := config.Backup.Next(from).Format("2006-01-02 15:04:05"); next != "2013-09-02 08:45:00" {
+		t.Errorf(`Backup.Next("2013-09-02 08:44:32") = "%s", expected "2013-09-02 08:45:00"`, next)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf(`json.Marshal() returned "%s"`, err)
+	}
+	expected := `{"Name":"backup","Schedule":"0 0 0 * * * *","Backup":"0 */5 * * * * *"}`
+	if string(data) != expected {
+		t.Errorf(`json.Marshal() = %s, expected %s`, data, expected)
+	}
+
+	// Zero and null values
+	data, _ = json.Marshal(jobConfig{Name: "none"})
+	if string(data) != `{"Name":"none","Schedule":""}` {
+		t.Errorf(`json.Marshal() = %s, expected {"Name":"none","Schedule":""}`, data)
+	}
+	config = jobConfig{}
+	if err = json.Unmarshal([]byte(`{"Schedule":"","Backup":null}`), &config); err != nil {
+		t.Errorf(`json.Unmarshal() returned "%s"`, err)
+	}
+	if config.Backup != nil || config.Schedule.isZero() == false {
+		t.Errorf(`json.Unmarshal() of empty and null schedules returned non-zero expressions`)
+	}
+}
+
+func TestJSONError(t *testing.T) {
+	var config jobConfig
+	err := json.Unmarshal([]byte(`{"Schedule":"0 0 * * FRIX"}`), &config)
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf(`json.Unmarshal() returned "%v", expected a *ParseError`, err)
+	}
+	if perr.Field != "day-of-week" || perr.Directive != "FRIX" {
+		t.Errorf(`json.Unmarshal() returned "%s"`, perr)
+	}
+
+	err = json.Unmarshal([]byte(`{"Schedule":42}`), &config)
+	if err == nil {
+		t.Errorf(`json.Unmarshal() of a number returned no error`)
+	}
+}
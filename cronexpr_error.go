@@ -0,0 +1,265 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_error.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strings"
+)
+
+/******************************************************************************/
+
+// ErrorKind identifies the reason why a cron expression was rejected.
+type ErrorKind int
+
+const (
+	// ErrorMissingFields: less than the 5 mandatory fields were supplied.
+	ErrorMissingFields ErrorKind = iota + 1
+	// ErrorMissingDirective: a field contains no directive, e.g. `,`.
+	ErrorMissingDirective
+	// ErrorSyntax: a directive is not understood.
+	ErrorSyntax
+	// ErrorInterval: the step of a directive is out of range, e.g. `*/0`.
+	ErrorInterval
+	// ErrorTimeZone: the `CRON_TZ=` or `TZ=` prefix names an unknown time
+	// zone.
+	ErrorTimeZone
+	// ErrorQuestionMark: `?` is misplaced as per the Quartz dialect.
+	ErrorQuestionMark
+	// ErrorHashSeed: an `H` directive is used but no seed was supplied.
+	ErrorHashSeed
+	// ErrorEvery: the duration or the anchor of an `@every` schedule is
+	// invalid.
+	ErrorEvery
+	// ErrorAmbiguousName: an abbreviated month or day-of-week name matches
+	// several names of the locale, e.g. `ma` in French, see WithLocale.
+	ErrorAmbiguousName
+	// ErrorEmptyRange: a range matches no value, e.g. `2030-2020` in the
+	// year field. Strict mode only, see WithStrict.
+	ErrorEmptyRange
+	// ErrorExtraFields: more than 7 fields were supplied. Strict mode only,
+	// fields beyond the seventh are otherwise ignored.
+	ErrorExtraFields
+	// ErrorOverlap: a directive matches a value already matched by another
+	// directive of the same field, e.g. `5` in `1-10,5`. Strict mode only.
+	ErrorOverlap
+	// ErrorStep: the step of a directive is larger than its range, e.g.
+	// `10-20/15`, hence it matches a single value. Strict mode only.
+	ErrorStep
+	// ErrorUnsatisfiable: the cron expression never fires, as no day of the
+	// months and years specified matches the day fields, e.g. `0 0 30 2 *`.
+	// Strict mode only.
+	ErrorUnsatisfiable
+	// ErrorMissingCommand: a crontab line has no command after its schedule,
+	// see ParseLine.
+	ErrorMissingCommand
+	// ErrorScan: a database value is not a cron expression, i.e. it is NULL
+	// or neither a string nor a byte slice, see Expression.Scan.
+	ErrorScan
+	// ErrorShortYear: a year of fewer than four digits is not part of an
+	// explicit range, e.g. `1` or `1/5`, which is most likely a misplaced
+	// value of another field. Such years require a range, e.g. `1-99`.
+	ErrorShortYear
+	// ErrorHashRange: an `H` directive has no range to pick a value from,
+	// i.e. its range is reversed, e.g. `H(30-10)`, or it is a bare `H` in the
+	// year field, which has no bounded default range, or a range ending with
+	// `7` in the day-of-week field, whose `H` ranges must stay within 0-6.
+	ErrorHashRange
+)
+
+var errorKindNames = map[ErrorKind]string{
+	ErrorMissingFields:    "missing fields",
+	ErrorMissingDirective: "missing directive",
+	ErrorSyntax:           "syntax error",
+	ErrorInterval:         "invalid interval",
+	ErrorTimeZone:         "unknown time zone",
+	ErrorQuestionMark:     "misplaced '?'",
+	ErrorHashSeed:         "missing hash seed",
+	ErrorEvery:            "invalid @every schedule",
+	ErrorAmbiguousName:    "ambiguous name",
+	ErrorEmptyRange:       "empty range",
+	ErrorExtraFields:      "extra fields",
+	ErrorOverlap:          "overlapping directives",
+	ErrorStep:             "step larger than range",
+	ErrorUnsatisfiable:    "unsatisfiable expression",
+	ErrorMissingCommand:   "missing command",
+	ErrorScan:             "unscannable value",
+	ErrorShortYear:        "short year",
+	ErrorHashRange:        "invalid hash range",
+}
+
+func (kind ErrorKind) String() string {
+	if name, ok := errorKindNames[kind]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(kind))
+}
+
+/******************************************************************************/
+
+// A ParseError describes why and where a cron expression was rejected by
+// Parse.
+type ParseError struct {
+	Kind ErrorKind
+	// Input is the cron expression as supplied to Parse.
+	Input string
+	// Field is the name of the offending field, i.e. "second", "minute",
+	// "hour", "day-of-month", "month", "day-of-week" or "year". It is empty
+	// when the error is not specific to a field.
+	Field string
+	// Begin and End are the byte offsets of the offending text in Input.
+	Begin, End int
+	// Directive is the offending text, i.e. Input[Begin:End].
+	Directive string
+}
+
+func (err *ParseError) Error() string {
+	switch err.Kind {
+	case ErrorMissingFields:
+		return "missing field(s)"
+	case ErrorMissingCommand:
+		return "missing command"
+	case ErrorScan:
+		if err.Directive == "NULL" {
+			return "cannot scan NULL into Expression, use NullExpression"
+		}
+		return fmt.Sprintf("cannot scan %s into Expression", err.Directive)
+	case ErrorMissingDirective:
+		return fmt.Sprintf("%s field: missing directive", err.Field)
+	case ErrorSyntax:
+		return fmt.Sprintf("syntax error in %s field: '%s'", err.Field, err.Directive)
+	case ErrorInterval:
+		return fmt.Sprintf("invalid interval %s", err.Directive)
+	case ErrorTimeZone:
+		return fmt.Sprintf("unknown time zone: '%s'", err.Directive)
+	case ErrorQuestionMark:
+		return fmt.Sprintf("misplaced '?' in %s field: '%s'", err.Field, err.Directive)
+	case ErrorHashSeed:
+		return fmt.Sprintf("missing hash seed for %s field: '%s'", err.Field, err.Directive)
+	case ErrorHashRange:
+		switch {
+		case err.Field == yearDescriptor.name && !strings.Contains(err.Directive, "("):
+			return fmt.Sprintf("H requires an explicit range in year field, which has no bounded default range: '%s'", err.Directive)
+		case err.Field == dowDescriptor.name:
+			return fmt.Sprintf("H range must be increasing and stay within 0-6 in day-of-week field: '%s'", err.Directive)
+		}
+		return fmt.Sprintf("H range must be increasing in %s field: '%s'", err.Field, err.Directive)
+	case ErrorEvery:
+		return fmt.Sprintf("invalid @every schedule: '%s'", err.Directive)
+	case ErrorAmbiguousName:
+		return fmt.Sprintf("ambiguous name in %s field: '%s'", err.Field, err.Directive)
+	case ErrorEmptyRange:
+		return fmt.Sprintf("empty range in %s field: '%s'", err.Field, err.Directive)
+	case ErrorExtraFields:
+		return fmt.Sprintf("extra field(s): '%s'", err.Directive)
+	case ErrorOverlap:
+		return fmt.Sprintf("overlapping directive in %s field: '%s'", err.Field, err.Directive)
+	case ErrorStep:
+		return fmt.Sprintf("step larger than range in %s field: '%s'", err.Field, err.Directive)
+	case ErrorShortYear:
+		return fmt.Sprintf("year of fewer than four digits outside of a range: '%s'", err.Directive)
+	case ErrorUnsatisfiable:
+		return fmt.Sprintf("%s field matches no day of the months and years specified: '%s'", err.Field, err.Directive)
+	}
+	if err.Field != "" {
+		return fmt.Sprintf("%s in %s field: '%s'", err.Kind, err.Field, err.Directive)
+	}
+	return err.Kind.String()
+}
+
+// Pretty returns a multi-line rendering of the error suitable for terminal
+// output: the input, a `^~~~` caret underlining the offending text, then the
+// error message.
+func (err *ParseError) Pretty() string {
+	var b strings.Builder
+	b.WriteString(err.Input)
+	b.WriteByte('\n')
+	begin, end := err.Begin, err.End
+	if begin > len(err.Input) {
+		begin = len(err.Input)
+	}
+	if end < begin {
+		end = begin
+	}
+	if end > len(err.Input) {
+		end = len(err.Input)
+	}
+	// Keep tabs so that the caret lines up with the input
+	for _, r := range err.Input[:begin] {
+		if r == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteByte('^')
+	for i := range []rune(err.Input[begin:end]) {
+		if i > 0 {
+			b.WriteByte('~')
+		}
+	}
+	b.WriteByte('\n')
+	b.WriteString(err.Error())
+	return b.String()
+}
+
+/******************************************************************************/
+
+// newDirectiveError returns an error about the directive `directive` of field
+// `s`. Offsets are relative to `s` until the error is relocated by Parse.
+func newDirectiveError(kind ErrorKind, desc fieldDescriptor, s string, directive *cronDirective) *ParseError {
+	return &ParseError{
+		Kind:      kind,
+		Input:     s,
+		Field:     desc.name,
+		Begin:     directive.sbeg,
+		End:       directive.send,
+		Directive: s[directive.sbeg:directive.send],
+	}
+}
+
+// newSyntaxError returns an ErrorSyntax error about the directive `directive`
+// of field `s`, or an ErrorAmbiguousName error if the directive contains an
+// ambiguous name.
+func newSyntaxError(desc fieldDescriptor, s string, directive *cronDirective) *ParseError {
+	kind := ErrorSyntax
+	if desc.ambiguous != nil {
+		for _, name := range nameFinder.FindAllString(strings.ToLower(s[directive.sbeg:directive.send]), -1) {
+			if desc.ambiguous(name) {
+				kind = ErrorAmbiguousName
+				break
+			}
+		}
+	}
+	return newDirectiveError(kind, desc, s, directive)
+}
+
+// relocate turns an error relative to a single field into an error relative
+// to the whole cron expression `cronLine`.
+func (field *cronField) relocate(err error, cronLine string) error {
+	perr, ok := err.(*ParseError)
+	if !ok {
+		return err
+	}
+	if field.alias {
+		perr.Begin, perr.End = field.beg, field.end
+	} else {
+		perr.Begin += field.beg
+		perr.End += field.beg
+	}
+	perr.Input = cronLine
+	perr.Directive = cronLine[perr.Begin:perr.End]
+	return perr
+}
@@ -0,0 +1,128 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_every.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"time"
+)
+
+/******************************************************************************/
+
+// parseEvery parses an `@every <duration>` schedule, which fires at fixed
+// intervals that need not fit in the fields of a cron expression, e.g.
+// `@every 90m` or `@every 36h`:
+//
+//	@every 90m
+//	@every 90m from 2024-01-01T00:00:00Z
+//
+// The duration is in the format accepted by time.ParseDuration, it must be a
+// whole number of seconds, at least one. Occurrences are aligned on the
+// anchor, an RFC 3339 time stamp, which is also the first occurrence; the
+// anchor defaults to the Unix epoch, 1970-01-01T00:00:00Z.
+//
+// Intervals are absolute durations, hence they are not affected by time zones
+// or daylight-saving time.
+func parseEvery(cronLine string, fields []cronField, tz *cronField, opts *parseOptions) (*Expression, error) {
+	var expr = Expression{expression: cronLine, location: opts.location}
+	var err error
+
+	// time zone (optional)
+	if tz != nil {
+		expr.location, err = parseLocation(tz, cronLine)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// duration
+	if len(fields) < 2 {
+		return nil, &ParseError{
+			Kind:  ErrorMissingFields,
+			Input: cronLine,
+			Begin: len(cronLine),
+			End:   len(cronLine),
+		}
+	}
+	d, err := time.ParseDuration(fields[1].s)
+	if err != nil || d < time.Second || d%time.Second != 0 {
+		return nil, newEveryError(cronLine, &fields[1])
+	}
+	expr.every = int64(d / time.Second)
+
+	// anchor (optional)
+	if len(fields) > 2 {
+		if fields[2].s != "from" || len(fields) == 3 {
+			return nil, newEveryError(cronLine, &fields[2])
+		}
+		anchor, err := time.Parse(time.RFC3339, fields[3].s)
+		if err != nil || anchor.Nanosecond() != 0 {
+			return nil, newEveryError(cronLine, &fields[3])
+		}
+		expr.anchor = anchor.Unix()
+	}
+	if len(fields) > 4 {
+		return nil, newEveryError(cronLine, &fields[4])
+	}
+
+	return &expr, nil
+}
+
+func newEveryError(cronLine string, field *cronField) *ParseError {
+	return &ParseError{
+		Kind:      ErrorEvery,
+		Input:     cronLine,
+		Begin:     field.beg,
+		End:       field.end,
+		Directive: field.s,
+	}
+}
+
+/******************************************************************************/
+
+func (expr *Expression) nextEvery(fromTime time.Time) time.Time {
+	elapsed := fromTime.Unix() - expr.anchor
+	n := int64(0)
+	if elapsed >= 0 {
+		n = elapsed/expr.every + 1
+	}
+	return time.Unix(expr.anchor+n*expr.every, 0).In(fromTime.Location())
+}
+
+func (expr *Expression) prevEvery(fromTime time.Time) time.Time {
+	// Unix() truncates, so `fromTime` itself is excluded only when it has no
+	// fractional second
+	secs := fromTime.Unix()
+	if fromTime.Nanosecond() == 0 {
+		secs -= 1
+	}
+	elapsed := secs - expr.anchor
+	if elapsed < 0 {
+		return time.Time{}
+	}
+	return time.Unix(expr.anchor+elapsed/expr.every*expr.every, 0).In(fromTime.Location())
+}
+
+func (expr *Expression) matchesEvery(t time.Time) bool {
+	elapsed := t.Unix() - expr.anchor
+	return elapsed >= 0 && elapsed%expr.every == 0
+}
+
+func (expr *Expression) formatEvery() string {
+	s := "@every " + (time.Duration(expr.every) * time.Second).String()
+	if expr.anchor != 0 {
+		s += " from " + time.Unix(expr.anchor, 0).UTC().Format(time.RFC3339)
+	}
+	return s
+}
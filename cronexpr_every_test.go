@@ -0,0 +1,169 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_every_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+var everytests = []crontest{
+	{
+		"@every 90m",
+		"2006-01-02 15:04:05",
+		[]crontimes{
+			{"2024-01-01 00:00:00", "2024-01-01 01:30:00"},
+			{"2024-01-01 01:29:59", "2024-01-01 01:30:00"},
+			{"2024-01-01 01:30:00", "2024-01-01 03:00:00"},
+			{"2024-01-01 23:59:59", "2024-01-02 00:00:00"},
+		},
+	},
+	{
+		"@every 36h",
+		"2006-01-02 15:04:05",
+		[]crontimes{
+			{"2024-01-01 00:00:00", "2024-01-01 12:00:00"},
+			{"2024-01-01 12:00:00", "2024-01-03 00:00:00"},
+		},
+	},
+	{
+		"@every 90m from 2024-01-01T00:10:00Z",
+		"2006-01-02 15:04:05",
+		[]crontimes{
+			{"2023-06-01 00:00:00", "2024-01-01 00:10:00"},
+			{"2024-01-01 00:10:00", "2024-01-01 01:40:00"},
+			{"2024-01-01 01:40:30", "2024-01-01 03:10:00"},
+		},
+	},
+	{
+		"@every 7s from 2024-01-01T01:00:00+01:00",
+		"2006-01-02 15:04:05",
+		[]crontimes{
+			{"2024-01-01 00:00:00", "2024-01-01 00:00:07"},
+			{"2024-01-01 00:00:59", "2024-01-01 00:01:03"},
+		},
+	},
+}
+
+func TestEvery(t *testing.T) {
+	for _, test := range everytests {
+		expr, err := Parse(test.expr)
+		if err != nil {
+			t.Errorf(`Parse("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		for _, times := range test.times {
+			from, _ := time.Parse("2006-01-02 15:04:05", times.from)
+			next := expr.Next(from)
+			if s := next.Format(test.layout); s != times.next {
+				t.Errorf(`("%s").Next("%s") = "%s", expected "%s"`, test.expr, times.from, s, times.next)
+			}
+			if !expr.Matches(next) || expr.Matches(next.Add(time.Second)) {
+				t.Errorf(`("%s").Matches("%s") is wrong`, test.expr, times.next)
+			}
+			// Walking back from the next time instant leads before `from`
+			if prev := expr.Prev(next); !prev.IsZero() && !prev.Before(from.Add(time.Second)) {
+				t.Errorf(`("%s").Prev("%s") = "%s", expected before "%s"`, test.expr, times.next, prev, times.from)
+			}
+		}
+	}
+}
+
+func TestEveryN(t *testing.T) {
+	expr := MustParse("@every 1h30m")
+	from := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	next := expr.NextN(from, 3)
+	prev := expr.PrevN(next[2], 2)
+	if len(next) != 3 || !next[1].Equal(prev[0]) || !next[0].Equal(prev[1]) {
+		t.Errorf(`NextN() = %v, PrevN() = %v`, next, prev)
+	}
+	for i := 1; i < len(next); i++ {
+		if next[i].Sub(next[i-1]) != 90*time.Minute {
+			t.Errorf(`NextN()[%d] = "%s", expected 90 minutes after "%s"`, i, next[i], next[i-1])
+		}
+	}
+
+	// Nothing before the anchor
+	expr = MustParse("@every 1h from 2024-01-01T00:00:00Z")
+	if prev := expr.Prev(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)); !prev.IsZero() {
+		t.Errorf(`Prev() = "%s", expected zero time`, prev)
+	}
+	if prev := expr.Prev(time.Date(2024, time.January, 1, 0, 0, 0, 1, time.UTC)); prev.IsZero() {
+		t.Errorf(`Prev() returned zero time, expected the anchor`)
+	}
+}
+
+// Intervals are absolute durations, unaffected by daylight-saving time.
+func TestEveryDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expr := MustParse("CRON_TZ=Europe/Berlin @every 1h")
+	from := time.Date(2024, time.March, 31, 1, 0, 0, 0, loc)
+	next := expr.NextN(from, 2)
+	if next[0].Format(time.RFC3339) != "2024-03-31T03:00:00+02:00" || next[1].Sub(next[0]) != time.Hour {
+		t.Errorf(`NextN() = %v`, next)
+	}
+}
+
+func TestEveryString(t *testing.T) {
+	tests := []struct{ expr, canonical string }{
+		{"@every 90m", "@every 1h30m0s"},
+		{"@every 36h", "@every 36h0m0s"},
+		{"@every 1s from 1970-01-01T00:00:00Z", "@every 1s"},
+		{"@every 1h from 2024-01-01T01:00:00+01:00", "@every 1h0m0s from 2024-01-01T00:00:00Z"},
+		{"TZ=UTC @every 15m", "CRON_TZ=UTC @every 15m0s"},
+	}
+	for _, test := range tests {
+		expr := MustParse(test.expr)
+		if s := expr.String(); s != test.canonical {
+			t.Errorf(`("%s").String() = "%s", expected "%s"`, test.expr, s, test.canonical)
+		}
+		if s := MustParse(test.canonical).String(); s != test.canonical {
+			t.Errorf(`("%s").String() = "%s"`, test.canonical, s)
+		}
+	}
+}
+
+var everyErrorTests = []parseErrorTest{
+	{"@every", ErrorMissingFields, "", "", 6},
+	{"@every 90", ErrorEvery, "", "90", 7},
+	{"@every 500ms", ErrorEvery, "", "500ms", 7},
+	{"@every 1.5s", ErrorEvery, "", "1.5s", 7},
+	{"@every -1h", ErrorEvery, "", "-1h", 7},
+	{"@every 1h since 2024-01-01T00:00:00Z", ErrorEvery, "", "since", 10},
+	{"@every 1h from", ErrorEvery, "", "from", 10},
+	{"@every 1h from 2024-01-01", ErrorEvery, "", "2024-01-01", 15},
+	{"@every 1h from 2024-01-01T00:00:00Z x", ErrorEvery, "", "x", 36},
+}
+
+func TestEveryError(t *testing.T) {
+	for _, test := range everyErrorTests {
+		_, err := Parse(test.expr)
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`Parse("%s") returned "%v", expected a *ParseError`, test.expr, err)
+			continue
+		}
+		if perr.Kind != test.kind || perr.Field != test.field || perr.Directive != test.directive || perr.Begin != test.begin {
+			t.Errorf(`Parse("%s") returned %v %q %q @%d, expected %v %q %q @%d`,
+				test.expr, perr.Kind, perr.Field, perr.Directive, perr.Begin,
+				test.kind, test.field, test.directive, test.begin)
+		}
+	}
+}
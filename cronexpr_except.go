@@ -0,0 +1,484 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_except.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// An Exclusion is a set of time ranges which a Schedule returned by Except
+// must skip, e.g. bank holidays or a maintenance window.
+type Exclusion interface {
+	// Excluded returns whether time instant `t` is excluded and, if so,
+	// the excluded time range [begin, end) containing `t`. The larger the
+	// time range, the fewer time instants of the base schedule Except has
+	// to examine.
+	Excluded(t time.Time) (begin, end time.Time, ok bool)
+}
+
+var (
+	_ Exclusion = (*Expression)(nil)
+	_ Exclusion = (*DateList)(nil)
+)
+
+// exception is a Schedule firing whenever its base schedule fires, except
+// during excluded time ranges.
+type exception struct {
+	base     Schedule
+	excluded []Exclusion
+}
+
+// Except returns a Schedule which fires whenever `base` fires, except during
+// the time ranges of `excluded`, e.g. "every weekday at 09:00 except bank
+// holidays":
+//
+//	holidays, err := cronexpr.LoadDateList("holidays.ics")
+//	...
+//	s := cronexpr.Except(cronexpr.MustParse("0 9 * * 1-5"), holidays)
+//
+// Excluded time ranges are skipped as a whole. A base schedule which falls in
+// excluded time ranges only is detected after a bounded number of attempts,
+// see SearchLimit, after which the zero time value is returned.
+func Except(base Schedule, excluded ...Exclusion) Schedule {
+	return &exception{base, append([]Exclusion(nil), excluded...)}
+}
+
+func (s *exception) Next(fromTime time.Time) time.Time {
+	t := s.base.Next(fromTime)
+	for n := 0; n < SearchLimit && t.IsZero() == false; n++ {
+		end, ok := s.exclusionEnd(t)
+		if !ok {
+			return t
+		}
+		// Excluded for good
+		if !end.Before(endOfTime) {
+			break
+		}
+		t = s.base.Next(end.Add(-time.Nanosecond))
+	}
+	return time.Time{}
+}
+
+func (s *exception) Prev(fromTime time.Time) time.Time {
+	t := s.base.Prev(fromTime)
+	for n := 0; n < SearchLimit && t.IsZero() == false; n++ {
+		begin, ok := s.exclusionBegin(t)
+		if !ok {
+			return t
+		}
+		if !begin.After(beginningOfTime) {
+			break
+		}
+		t = s.base.Prev(begin)
+	}
+	return time.Time{}
+}
+
+// exclusionEnd returns the end of the excluded time range containing `t`, if
+// any. Only one bound of the time range is computed whenever possible.
+func (s *exception) exclusionEnd(t time.Time) (time.Time, bool) {
+	for _, excluded := range s.excluded {
+		if directed, ok := excluded.(directedExclusion); ok {
+			if end, ok := directed.excludedUntil(t); ok {
+				return end, true
+			}
+		} else if _, end, ok := excluded.Excluded(t); ok {
+			return end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// exclusionBegin returns the beginning of the excluded time range containing
+// `t`, if any.
+func (s *exception) exclusionBegin(t time.Time) (time.Time, bool) {
+	for _, excluded := range s.excluded {
+		if directed, ok := excluded.(directedExclusion); ok {
+			if begin, ok := directed.excludedSince(t); ok {
+				return begin, true
+			}
+		} else if begin, _, ok := excluded.Excluded(t); ok {
+			return begin, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// A directedExclusion computes either bound of an excluded time range on its
+// own, at a lower cost than both.
+type directedExclusion interface {
+	excludedUntil(t time.Time) (time.Time, bool)
+	excludedSince(t time.Time) (time.Time, bool)
+}
+
+/******************************************************************************/
+
+// The excluded time range around a matching time instant is extended at most
+// this many steps in each direction, a step being one unit, see
+// exclusionUnit(), or a whole month or year when the cron expression matches
+// all of it.
+const exclusionMaxUnits = 1000
+
+// The bounds of the excluded time range of a cron expression which always
+// matches.
+var (
+	beginningOfTime = time.Time{}
+	endOfTime       = time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Excluded implements the Exclusion interface. A cron expression excludes the
+// seconds during which it fires, or, if it fires at second 0 only, the whole
+// minutes during which it fires, e.g. `* 2 * * *` excludes 02:00:00 to
+// 02:59:59 every day. A cron expression which always matches, e.g.
+// `* * * * * * *`, excludes the zero time value to year 10000.
+func (expr *Expression) Excluded(t time.Time) (time.Time, time.Time, bool) {
+	begin, ok := expr.excludedSince(t)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	end, _ := expr.excludedUntil(t)
+	return begin, end, true
+}
+
+// excludedUntil returns the end of the excluded time range containing `t`.
+func (expr *Expression) excludedUntil(t time.Time) (time.Time, bool) {
+	if expr.members != nil {
+		for _, member := range expr.members {
+			if end, ok := member.(*Expression).excludedUntil(t); ok {
+				return end, true
+			}
+		}
+		return time.Time{}, false
+	}
+	if expr.location != nil {
+		t = t.In(expr.location)
+	}
+	unit := expr.exclusionUnit()
+	begin := truncateToUnit(t, unit)
+	if !expr.Matches(begin) {
+		return time.Time{}, false
+	}
+	if expr.alwaysMatches() {
+		return endOfTime, true
+	}
+	// Extend the excluded time range as far as the cron expression matches
+	end := addUnits(begin, unit, 1)
+	for n := 0; n < exclusionMaxUnits; n++ {
+		after, ok := expr.matchedAfter(end, unit)
+		if !ok {
+			break
+		}
+		end = after
+	}
+	return end, true
+}
+
+// excludedSince returns the beginning of the excluded time range containing
+// `t`.
+func (expr *Expression) excludedSince(t time.Time) (time.Time, bool) {
+	if expr.members != nil {
+		for _, member := range expr.members {
+			if begin, ok := member.(*Expression).excludedSince(t); ok {
+				return begin, true
+			}
+		}
+		return time.Time{}, false
+	}
+	if expr.location != nil {
+		t = t.In(expr.location)
+	}
+	unit := expr.exclusionUnit()
+	begin := truncateToUnit(t, unit)
+	if !expr.Matches(begin) {
+		return time.Time{}, false
+	}
+	if expr.alwaysMatches() {
+		return beginningOfTime, true
+	}
+	for n := 0; n < exclusionMaxUnits; n++ {
+		before, ok := expr.matchedBefore(begin, unit)
+		if !ok {
+			break
+		}
+		begin = before
+	}
+	return begin, true
+}
+
+// matchedAfter returns the end of the longest time period starting at `t`,
+// i.e. a unit, a month or a year, during which the cron expression matches
+// all the time.
+func (expr *Expression) matchedAfter(t time.Time, unit time.Duration) (time.Time, bool) {
+	if unit == 24*time.Hour && t.Day() == 1 && expr.matchesWholeMonths() {
+		if t.Month() == time.January && len(expr.monthList) == 12 && expr.Matches(t) {
+			return t.AddDate(1, 0, 0), true
+		}
+		if expr.Matches(t) {
+			return t.AddDate(0, 1, 0), true
+		}
+	}
+	if expr.Matches(t) {
+		return addUnits(t, unit, 1), true
+	}
+	return t, false
+}
+
+// matchedBefore returns the beginning of the longest time period ending at
+// `t` during which the cron expression matches all the time.
+func (expr *Expression) matchedBefore(t time.Time, unit time.Duration) (time.Time, bool) {
+	if unit == 24*time.Hour && t.Day() == 1 && expr.matchesWholeMonths() {
+		if year := t.AddDate(-1, 0, 0); t.Month() == time.January && len(expr.monthList) == 12 && expr.Matches(year) {
+			return year, true
+		}
+		if month := t.AddDate(0, -1, 0); expr.Matches(month) {
+			return month, true
+		}
+	}
+	if before := addUnits(t, unit, -1); expr.Matches(before) {
+		return before, true
+	}
+	return t, false
+}
+
+// matchesWholeMonths returns whether the cron expression matches all the time
+// during the months and years it matches.
+func (expr *Expression) matchesWholeMonths() bool {
+	return expr.exclusionUnit() == 24*time.Hour && !expr.daysOfMonthRestricted && !expr.daysOfWeekRestricted
+}
+
+func (expr *Expression) alwaysMatches() bool {
+	return expr.matchesWholeMonths() && len(expr.monthList) == 12 && expr.yearList == nil
+}
+
+// exclusionUnit returns the smallest time unit during which the cron
+// expression either fires all the time or not at all.
+func (expr *Expression) exclusionUnit() time.Duration {
+	if expr.every > 0 {
+		return time.Second
+	}
+	if len(expr.secondList) < 60 && (len(expr.secondList) != 1 || expr.secondList[0] != 0) {
+		return time.Second
+	}
+	if len(expr.minuteList) < 60 {
+		return time.Minute
+	}
+	if len(expr.hourList) < 24 {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+func truncateToUnit(t time.Time, unit time.Duration) time.Time {
+	year, month, day := t.Date()
+	switch unit {
+	case 24 * time.Hour:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	case time.Hour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	}
+	return t.Truncate(unit)
+}
+
+func addUnits(t time.Time, unit time.Duration, n int) time.Time {
+	if unit == 24*time.Hour {
+		return t.AddDate(0, 0, n)
+	}
+	return t.Add(time.Duration(n) * unit)
+}
+
+/******************************************************************************/
+
+// A DateList is an Exclusion made of whole calendar days, e.g. bank holidays.
+// A day is excluded from midnight to midnight in the `time.Location` of the
+// time instants of the base schedule.
+type DateList struct {
+	days map[date]bool
+}
+
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// Dates returns a DateList made of the calendar days of `dates`, as seen in
+// their own `time.Location`.
+func Dates(dates ...time.Time) *DateList {
+	list := &DateList{days: make(map[date]bool)}
+	for _, t := range dates {
+		year, month, day := t.Date()
+		list.days[date{year, month, day}] = true
+	}
+	return list
+}
+
+// Len returns the number of days in the DateList.
+func (list *DateList) Len() int {
+	return len(list.days)
+}
+
+// Excluded implements the Exclusion interface.
+func (list *DateList) Excluded(t time.Time) (time.Time, time.Time, bool) {
+	year, month, day := t.Date()
+	if !list.days[date{year, month, day}] {
+		return time.Time{}, time.Time{}, false
+	}
+	begin := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	end := begin.AddDate(0, 0, 1)
+	// Consecutive days are excluded as a whole
+	for list.contains(end) {
+		end = end.AddDate(0, 0, 1)
+	}
+	for list.contains(begin.AddDate(0, 0, -1)) {
+		begin = begin.AddDate(0, 0, -1)
+	}
+	return begin, end, true
+}
+
+func (list *DateList) contains(t time.Time) bool {
+	year, month, day := t.Date()
+	return list.days[date{year, month, day}]
+}
+
+/******************************************************************************/
+
+// LoadDateList reads a DateList from file `name`, see ParseDateList.
+func LoadDateList(name string) (*DateList, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseDateList(f)
+}
+
+// ParseDateList reads a DateList from either an iCalendar (ICS) file or a
+// plain text file.
+//
+// A plain text file has one `YYYY-MM-DD` date per line, optionally followed by
+// a description. Blank lines and lines starting with `#` are ignored:
+//
+//	# Bank holidays
+//	2024-12-25 Christmas Day
+//	2024-12-26 Boxing Day
+//
+// With an iCalendar file, the days from DTSTART to DTEND of each VEVENT are
+// excluded. Recurrence rules are not supported.
+func ParseDateList(r io.Reader) (*DateList, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// iCalendar content lines may be folded
+		if len(lines) > 0 && len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.EqualFold(line, "BEGIN:VCALENDAR") {
+			return parseICS(lines)
+		}
+		break
+	}
+	return parseDateLines(lines)
+}
+
+func parseDateLines(lines []string) (*DateList, error) {
+	list := Dates()
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date '%s'", i+1, fields[0])
+		}
+		list.days[date{t.Year(), t.Month(), t.Day()}] = true
+	}
+	return list, nil
+}
+
+func parseICS(lines []string) (*DateList, error) {
+	list := Dates()
+	var start, end time.Time
+	inEvent := false
+	for i, line := range lines {
+		name, value := line, ""
+		if colon := strings.IndexByte(line, ':'); colon >= 0 {
+			name, value = line[:colon], line[colon+1:]
+		}
+		// Drop parameters, e.g. `DTSTART;VALUE=DATE`
+		if semicolon := strings.IndexByte(name, ';'); semicolon >= 0 {
+			name = name[:semicolon]
+		}
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end = time.Time{}, time.Time{}
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			// Only the date matters, e.g. `20241225` or `20241225T090000Z`
+			if len(value) < 8 {
+				return nil, fmt.Errorf("line %d: invalid date '%s'", i+1, value)
+			}
+			t, err := time.Parse("20060102", value[:8])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid date '%s'", i+1, value)
+			}
+			if strings.EqualFold(name, "DTSTART") {
+				start = t
+			} else {
+				end = t
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			// DTEND is exclusive
+			if !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for t := start; t.Before(end); t = t.AddDate(0, 0, 1) {
+				list.days[date{t.Year(), t.Month(), t.Day()}] = true
+			}
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,214 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_except_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+// Counts calls to Next and Prev, to make sure excluded time ranges are
+// skipped as a whole.
+type countingSchedule struct {
+	Schedule
+	calls int
+}
+
+func (s *countingSchedule) Next(fromTime time.Time) time.Time {
+	s.calls += 1
+	return s.Schedule.Next(fromTime)
+}
+
+func (s *countingSchedule) Prev(fromTime time.Time) time.Time {
+	s.calls += 1
+	return s.Schedule.Prev(fromTime)
+}
+
+/******************************************************************************/
+
+func TestExceptHolidays(t *testing.T) {
+	holidays := Dates(
+		time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 26, 0, 0, 0, 0, time.UTC),
+	)
+	s := Except(MustParse("0 9 * * 1-5"), holidays)
+	from, _ := time.Parse(time.RFC3339, "2024-12-24T12:00:00Z")
+	next := formatTimes(NextN(s, from, 2))
+	expected := []string{"2024-12-27T09:00:00Z", "2024-12-30T09:00:00Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Except()) = %v, expected %v`, next, expected)
+	}
+	prev := formatTimes(PrevN(s, time.Date(2024, time.December, 27, 9, 0, 0, 0, time.UTC), 2))
+	expected = []string{"2024-12-24T09:00:00Z", "2024-12-23T09:00:00Z"}
+	if !equalStrings(prev, expected) {
+		t.Errorf(`PrevN(Except()) = %v, expected %v`, prev, expected)
+	}
+}
+
+func TestExceptMaintenanceWindow(t *testing.T) {
+	base := &countingSchedule{Schedule: MustParse("*/5 * * * * * *")}
+	s := Except(base, MustParse("* 2 * * *"))
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T01:59:50Z")
+	next := formatTimes(NextN(s, from, 3))
+	expected := []string{"2024-01-01T01:59:55Z", "2024-01-01T03:00:00Z", "2024-01-01T03:00:05Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Except()) = %v, expected %v`, next, expected)
+	}
+	// 720 excluded time instants, yet only a handful of calls
+	if base.calls > 10 {
+		t.Errorf(`NextN(Except()) called Next() %d times`, base.calls)
+	}
+	prev := formatTimes(PrevN(s, time.Date(2024, time.January, 1, 3, 0, 0, 0, time.UTC), 1))
+	expected = []string{"2024-01-01T01:59:55Z"}
+	if !equalStrings(prev, expected) {
+		t.Errorf(`PrevN(Except()) = %v, expected %v`, prev, expected)
+	}
+
+	// Several exclusions, whole days and a union
+	s = Except(MustParse("0 */6 * * *"), MustParse("* * * * SAT,SUN"), MustParse("* 0-5 * * * | * 18-23 * * *"))
+	from, _ = time.Parse(time.RFC3339, "2024-01-05T13:00:00Z") // Friday
+	next = formatTimes(NextN(s, from, 2))
+	expected = []string{"2024-01-08T06:00:00Z", "2024-01-08T12:00:00Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Except()) = %v, expected %v`, next, expected)
+	}
+
+	// Everything excluded
+	s = Except(MustParse("0 0 0 * * * 2024-2025"), MustParse("* * * * *"))
+	if next := s.Next(from); !next.IsZero() {
+		t.Errorf(`Except().Next() = "%s", expected zero time`, next)
+	}
+}
+
+func TestExceptAlwaysExcluded(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	tests := []struct {
+		base, excluded string
+	}{
+		{"* * * * * * *", "* * * * * * *"},
+		{"* * * * * * *", "* * * * *"},
+		{"0 * * * * * 2024-2030", "* * * * * * 2020-2040"},
+	}
+	for _, test := range tests {
+		s := Except(MustParse(test.base), MustParse(test.excluded))
+		done := make(chan [2]time.Time, 1)
+		go func() {
+			done <- [2]time.Time{s.Next(from), s.Prev(from.AddDate(10, 0, 0))}
+		}()
+		select {
+		case times := <-done:
+			if !times[0].IsZero() || !times[1].IsZero() {
+				t.Errorf(`Except("%s", "%s") = "%s", "%s", expected zero times`, test.base, test.excluded, times[0], times[1])
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf(`Except("%s", "%s") did not return within 2 seconds`, test.base, test.excluded)
+		}
+	}
+}
+
+func TestExpressionExcluded(t *testing.T) {
+	tests := []struct {
+		expr, at, begin, end string
+	}{
+		{"* 2 * * *", "2024-01-01T02:30:15Z", "2024-01-01T02:00:00Z", "2024-01-01T03:00:00Z"},
+		{"* 2-4 * * *", "2024-01-01T02:30:15Z", "2024-01-01T02:00:00Z", "2024-01-01T05:00:00Z"},
+		{"30 2 * * *", "2024-01-01T02:30:15Z", "2024-01-01T02:30:00Z", "2024-01-01T02:31:00Z"},
+		{"*/2 * * * * * *", "2024-01-01T02:30:14Z", "2024-01-01T02:30:14Z", "2024-01-01T02:30:15Z"},
+		{"* * 24-26 12 *", "2024-12-25T12:00:00Z", "2024-12-24T00:00:00Z", "2024-12-27T00:00:00Z"},
+		{"* * * 3-5 *", "2024-04-15T12:00:00Z", "2024-03-01T00:00:00Z", "2024-06-01T00:00:00Z"},
+		{"* * * * * * 2024-2025", "2024-04-15T12:00:00Z", "2024-01-01T00:00:00Z", "2026-01-01T00:00:00Z"},
+	}
+	for _, test := range tests {
+		at, _ := time.Parse(time.RFC3339, test.at)
+		begin, end, ok := MustParse(test.expr).Excluded(at)
+		if !ok || begin.Format(time.RFC3339) != test.begin || end.Format(time.RFC3339) != test.end {
+			t.Errorf(`("%s").Excluded("%s") = "%s", "%s", %v, expected "%s", "%s"`, test.expr, test.at, begin, end, ok, test.begin, test.end)
+		}
+	}
+	at, _ := time.Parse(time.RFC3339, "2024-01-01T03:00:00Z")
+	if _, _, ok := MustParse("* 2 * * *").Excluded(at); ok {
+		t.Errorf(`("* 2 * * *").Excluded("%s") returned 'true'`, at)
+	}
+}
+
+/******************************************************************************/
+
+func TestParseDateList(t *testing.T) {
+	text := "# Bank holidays\n\n2024-12-25 Christmas Day\r\n2024-12-26\tBoxing Day\n"
+	list, err := ParseDateList(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Len() != 2 {
+		t.Errorf(`ParseDateList() returned %d days, expected 2`, list.Len())
+	}
+	begin, end, ok := list.Excluded(time.Date(2024, time.December, 26, 12, 0, 0, 0, time.UTC))
+	if !ok || begin.Day() != 25 || end.Day() != 27 {
+		t.Errorf(`Excluded() = "%s", "%s", %v`, begin, end, ok)
+	}
+
+	if _, err = ParseDateList(strings.NewReader("2024-12-25\n25/12/2024\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf(`ParseDateList() returned "%v", expected an error on line 2`, err)
+	}
+}
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"  Day\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241227\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:New Year's Day\r\n" +
+	"DTSTART;VALUE=DATE:20250101\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Offsite\r\n" +
+	"DTSTART:20250115T090000Z\r\n" +
+	"DTEND:20250115T170000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestLoadDateListICS(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "holidays.ics")
+	if err := os.WriteFile(name, []byte(testICS), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	list, err := LoadDateList(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Len() != 4 {
+		t.Errorf(`LoadDateList() returned %d days, expected 4`, list.Len())
+	}
+	s := Except(MustParse("0 9 * * 1-5"), list)
+	from, _ := time.Parse(time.RFC3339, "2024-12-24T12:00:00Z")
+	next := formatTimes(NextN(s, from, 4))
+	expected := []string{"2024-12-27T09:00:00Z", "2024-12-30T09:00:00Z", "2024-12-31T09:00:00Z", "2025-01-02T09:00:00Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Except()) = %v, expected %v`, next, expected)
+	}
+
+	if _, err = LoadDateList(filepath.Join(t.TempDir(), "missing.ics")); err == nil {
+		t.Errorf(`LoadDateList() of a missing file returned no error`)
+	}
+}
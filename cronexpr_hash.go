@@ -0,0 +1,106 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_hash.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"hash/fnv"
+)
+
+/******************************************************************************/
+
+// ParseWithSeed is like Parse, except that Jenkins-style `H` directives are
+// resolved using `seed`, typically the name of a job, see WithHashSeed().
+func ParseWithSeed(cronLine, seed string) (*Expression, error) {
+	return ParseWithOptions(cronLine, WithHashSeed(seed))
+}
+
+// WithHashSeed allows Jenkins-style `H` (hash) directives, which spread the
+// load of many jobs sharing the same schedule over time, and sets the seed
+// they are resolved from:
+//
+//	H          one value within the whole range of the field
+//	H(0-29)    one value within 0 and 29
+//	H/15       every 15 units, starting from one value within 0 and 14
+//	H(0-29)/10 every 10 units within 0 and 29, starting from one value
+//	           within 0 and 9
+//
+// The same seed always yields the same values, e.g. `H * * * *` fires at the
+// same minute of every hour across restarts, while different seeds spread
+// over the range of the field. In the day-of-month field, `H` and `H/n`
+// range from 1 to 28 so as to fire every month. In the year field, `H`
+// requires an explicit range, and in the day-of-week field, `H` ranges must
+// stay within 0-6, see ErrorHashRange.
+func WithHashSeed(seed string) Option {
+	return func(opts *parseOptions) {
+		opts.seed = &seed
+	}
+}
+
+// hashValue returns a value within [0, n) derived from `seed` and the name
+// of the field, so that the fields of the same cron expression are resolved
+// independently of each other.
+func hashValue(seed string, desc fieldDescriptor, n int) int {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	h.Write([]byte{0})
+	h.Write([]byte(desc.name))
+	return int(h.Sum64() % uint64(n))
+}
+
+/******************************************************************************/
+
+// parseHashDirective resolves the `H` directive `snormal` of field `s` into
+// `directive`. It returns false if `snormal` is not an `H` directive.
+func parseHashDirective(s, snormal string, desc fieldDescriptor, seed *string, directive *cronDirective) (bool, error) {
+	pairs := makeLayoutRegexp(layoutHash, desc.valuePattern).FindStringSubmatchIndex(snormal)
+	if len(pairs) == 0 {
+		return false, nil
+	}
+	if seed == nil {
+		return true, newDirectiveError(ErrorHashSeed, desc, s, directive)
+	}
+	first, last := desc.min, desc.hashMax
+	// `H(0-29)`
+	if pairs[2] >= 0 {
+		first = desc.atoi(snormal[pairs[2]:pairs[3]])
+		last = desc.atoi(snormal[pairs[4]:pairs[5]])
+		// `7` is Sunday, i.e. 0, which would silently narrow `H(0-7)`
+		if desc.rangeBound(snormal[pairs[2]:pairs[3]]) > desc.max || desc.rangeBound(snormal[pairs[4]:pairs[5]]) > desc.max {
+			return true, newDirectiveError(ErrorHashRange, desc, s, directive)
+		}
+	}
+	if last < first {
+		return true, newDirectiveError(ErrorHashRange, desc, s, directive)
+	}
+	// `H`
+	if pairs[6] < 0 {
+		directive.kind = one
+		directive.first = first + hashValue(*seed, desc, last-first+1)
+		return true, nil
+	}
+	// `H/15`
+	directive.kind = span
+	directive.step = atoi(snormal[pairs[6]:pairs[7]])
+	if directive.step < 1 || directive.step > desc.max {
+		return true, newDirectiveError(ErrorInterval, desc, s, directive)
+	}
+	n := directive.step
+	if n > last-first+1 {
+		n = last - first + 1
+	}
+	directive.first = first + hashValue(*seed, desc, n)
+	directive.last = last
+	return true, nil
+}
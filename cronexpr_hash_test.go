@@ -0,0 +1,120 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_hash_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"strconv"
+	"testing"
+)
+
+/******************************************************************************/
+
+// Resolved values must never change from one release to the next, lest all
+// schedules move at once.
+var hashTests = []struct {
+	expr      string
+	canonical string
+}{
+	{"H H(9-17) * * *", "0 5 13 * * * *"},
+	{"H/15 * * * *", "0 5-50/15 * * * * *"},
+	{"0 0 H * *", "0 0 0 23 * * *"},
+	{"H(0-29)/10 H * * H(1-5)", "0 5-25/10 7 * * 4 *"},
+	{"h h(9-17) * * *", "0 5 13 * * * *"},
+	{"0 0 1 1 * H(2030-2039)", "0 0 0 1 1 * 2030"},
+}
+
+func TestHash(t *testing.T) {
+	for _, test := range hashTests {
+		expr, err := ParseWithSeed(test.expr, "nightly-backup")
+		if err != nil {
+			t.Errorf(`ParseWithSeed("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		if s := expr.String(); s != test.canonical {
+			t.Errorf(`ParseWithSeed("%s").String() = "%s", expected "%s"`, test.expr, s, test.canonical)
+		}
+	}
+}
+
+func TestHashSpread(t *testing.T) {
+	seconds := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		seed := "job-" + strconv.Itoa(i)
+		expr, err := ParseWithSeed("H H(0-29)/10 H H * * *", seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Same seed, same schedule
+		if again, _ := ParseWithSeed("H H(0-29)/10 H H * * *", seed); again.String() != expr.String() {
+			t.Errorf(`seed "%s" yields "%s" then "%s"`, seed, expr, again)
+		}
+		if len(expr.secondList) != 1 || len(expr.hourList) != 1 || len(expr.daysOfMonth) != 1 {
+			t.Errorf(`seed "%s" yields "%s", expected single values`, seed, expr)
+		}
+		if len(expr.minuteList) != 3 || expr.minuteList[0] > 9 || expr.minuteList[1] != expr.minuteList[0]+10 {
+			t.Errorf(`seed "%s" yields "%s", expected 3 minutes in 0-29 every 10 minutes`, seed, expr)
+		}
+		for dom := range expr.daysOfMonth {
+			if dom < 1 || dom > 28 {
+				t.Errorf(`seed "%s" yields "%s", expected a day of month in 1-28`, seed, expr)
+			}
+		}
+		seconds[expr.secondList[0]] = true
+	}
+	if len(seconds) < 30 {
+		t.Errorf(`100 seeds yield only %d distinct seconds`, len(seconds))
+	}
+}
+
+var hashErrorTests = []parseErrorTest{
+	{"0 0 1 1 * H", ErrorHashRange, "year", "H", 10},
+	{"0 0 * * H(1-7)", ErrorHashRange, "day-of-week", "H(1-7)", 8},
+	{"0 0 * * H(0-7)", ErrorHashRange, "day-of-week", "H(0-7)", 8},
+	{"H(30-10) * * * *", ErrorHashRange, "minute", "H(30-10)", 0},
+	{"H/0 * * * *", ErrorInterval, "minute", "H/0", 0},
+	{"0 H(0-24) * * *", ErrorSyntax, "hour", "H(0-24)", 2},
+	{"0 0 * * MON,HX", ErrorSyntax, "day-of-week", "HX", 12},
+}
+
+func TestHashError(t *testing.T) {
+	// No seed
+	_, err := Parse("0 H * * *")
+	if perr, ok := err.(*ParseError); !ok || perr.Kind != ErrorHashSeed || perr.Field != "hour" || perr.Begin != 2 {
+		t.Errorf(`Parse("0 H * * *") returned "%v", expected a missing hash seed error`, err)
+	}
+	// The error tells why `H` is not allowed
+	messages := map[string]string{
+		"0 0 1 1 * H":    "H requires an explicit range in year field, which has no bounded default range: 'H'",
+		"0 0 * * H(1-7)": "H range must be increasing and stay within 0-6 in day-of-week field: 'H(1-7)'",
+	}
+	for expr, expected := range messages {
+		if _, err := ParseWithSeed(expr, "nightly-backup"); err == nil || err.Error() != expected {
+			t.Errorf(`ParseWithSeed("%s") returned "%v", expected "%s"`, expr, err, expected)
+		}
+	}
+	for _, test := range hashErrorTests {
+		_, err := ParseWithSeed(test.expr, "nightly-backup")
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`ParseWithSeed("%s") returned "%v", expected a *ParseError`, test.expr, err)
+			continue
+		}
+		if perr.Kind != test.kind || perr.Field != test.field || perr.Directive != test.directive || perr.Begin != test.begin {
+			t.Errorf(`ParseWithSeed("%s") returned %v %q %q @%d, expected %v %q %q @%d`,
+				test.expr, perr.Kind, perr.Field, perr.Directive, perr.Begin,
+				test.kind, test.field, test.directive, test.begin)
+		}
+	}
+}
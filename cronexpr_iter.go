@@ -0,0 +1,55 @@
+//go:build go1.23
+
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_iter.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"iter"
+	"time"
+)
+
+/******************************************************************************/
+
+// All returns an iterator over all the time instants which match the cron
+// expression `expr` immediately following `fromTime`, in chronological
+// ascending order. Time instants are computed lazily, one at a time.
+//
+// The iteration ends when no more matching time instant exists.
+func (expr *Expression) All(fromTime time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := expr.Next(fromTime); !t.IsZero(); t = expr.successor(t) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over all the time instants which match the cron
+// expression `expr`, from `fromTime` inclusively to `toTime` exclusively, in
+// chronological ascending order. It is the lazy counterpart of Between().
+func (expr *Expression) Range(fromTime, toTime time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if fromTime.IsZero() {
+			return
+		}
+		for t := expr.Next(fromTime.Add(-time.Nanosecond)); !t.IsZero() && t.Before(toTime); t = expr.successor(t) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
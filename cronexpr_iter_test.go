@@ -0,0 +1,83 @@
+//go:build go1.23
+
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_iter_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+func TestAll(t *testing.T) {
+	from, _ := time.Parse("2006-01-02 15:04:05", "2013-09-02 08:44:30")
+	expr := MustParse("0 0 * * 6#5")
+	expected := expr.NextN(from, 20)
+	i := 0
+	for next := range expr.All(from) {
+		if !next.Equal(expected[i]) {
+			t.Errorf(`("0 0 * * 6#5").All()[%d] = "%s", expected "%s"`, i, next, expected[i])
+		}
+		i += 1
+		if i == len(expected) {
+			break
+		}
+	}
+	if i != len(expected) {
+		t.Errorf(`("0 0 * * 6#5").All() yielded %d time values, expected %d`, i, len(expected))
+	}
+
+	// Iteration ends by itself when there is no more matching time instants
+	n := 0
+	for range MustParse("0 0 0 1 1 * 2013-2015").All(from) {
+		n += 1
+	}
+	if n != 2 {
+		t.Errorf(`("0 0 0 1 1 * 2013-2015").All() yielded %d time values, expected 2`, n)
+	}
+}
+
+func TestRange(t *testing.T) {
+	// A whole month of a per-second schedule, never held in memory at once
+	from, _ := time.Parse("2006-01-02", "2013-01-01")
+	to, _ := time.Parse("2006-01-02", "2013-02-01")
+	expr := MustParse("* * * * * * *")
+	n := 0
+	var last time.Time
+	for next := range expr.Range(from, to) {
+		if n == 0 && !next.Equal(from) {
+			t.Fatalf(`("* * * * * * *").Range() started at "%s", expected "%s"`, next, from)
+		}
+		n += 1
+		last = next
+	}
+	if n != 31*24*3600 || !last.Equal(to.Add(-time.Second)) {
+		t.Errorf(`("* * * * * * *").Range() yielded %d time values up to "%s"`, n, last)
+	}
+
+	expected := MustParse("*/5 * * * *").Between(from, from.Add(time.Hour))
+	i := 0
+	for next := range MustParse("*/5 * * * *").Range(from, from.Add(time.Hour)) {
+		if !next.Equal(expected[i]) {
+			t.Errorf(`("*/5 * * * *").Range()[%d] = "%s", expected "%s"`, i, next, expected[i])
+		}
+		i += 1
+	}
+	if i != 12 {
+		t.Errorf(`("*/5 * * * *").Range() yielded %d time values, expected 12`, i)
+	}
+}
@@ -0,0 +1,150 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_line.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"strings"
+	"time"
+)
+
+/******************************************************************************/
+
+// LineLayout tells how the schedule of a crontab line is laid out, see
+// ParseLine.
+type LineLayout int
+
+const (
+	// LayoutVixie: 5 fields, then the command, as in a user crontab.
+	LayoutVixie LineLayout = iota
+	// LayoutSystem: 5 fields, then the user, then the command, as in
+	// `/etc/crontab`.
+	LayoutSystem
+	// LayoutSixFields: 5 fields and the year field, then the command, or the
+	// second field and 5 fields with the Quartz dialect.
+	LayoutSixFields
+	// LayoutSevenFields: the second field, 5 fields and the year field, then
+	// the command.
+	LayoutSevenFields
+)
+
+// A Line is a crontab line split into its schedule and its command. Offsets
+// are byte offsets in the line, so that the schedule can be rewritten in
+// place, e.g. `line[:l.ScheduleBegin] + "0 3 * * *" + line[l.ScheduleEnd:]`.
+type Line struct {
+	Expression *Expression
+	// User is the user column of a system crontab, empty otherwise.
+	User string
+	// Command is the remainder of the line, verbatim, without the trailing
+	// line terminator if any.
+	Command string
+
+	ScheduleBegin, ScheduleEnd int
+	UserBegin, UserEnd         int
+	CommandBegin, CommandEnd   int
+}
+
+/******************************************************************************/
+
+// ParseLine splits the crontab line `line` laid out as per `layout` into a
+// cron expression, parsed with the supplied options, and a command.
+//
+// The schedule may be a built-in alias, e.g. `@daily`, or an `@every`
+// schedule, in place of the fields of the layout, and may be preceded by a
+// `CRON_TZ=` or `TZ=` prefix. The `from` of an `@every` schedule starts the
+// command unless it is followed by an RFC 3339 time stamp. An error is
+// returned if the schedule is malformed, it is always of type *ParseError
+// with offsets in `line`.
+func ParseLine(line string, layout LineLayout, options ...Option) (*Line, error) {
+	end := len(line)
+	if strings.HasSuffix(line, "\n") {
+		end -= 1
+		if strings.HasSuffix(line[:end], "\r") {
+			end -= 1
+		}
+	}
+	indices := fieldFinder.FindAllStringIndex(line[:end], -1)
+
+	// How many whitespace-separated fields make up the schedule
+	count := 0
+	if len(indices) > 0 {
+		s := line[indices[0][0]:indices[0][1]]
+		if strings.HasPrefix(s, "CRON_TZ=") || strings.HasPrefix(s, "TZ=") {
+			count = 1
+		}
+	}
+	var s string
+	if count < len(indices) {
+		s = line[indices[count][0]:indices[count][1]]
+	}
+	switch {
+	case s == "@every":
+		count += 2
+		// `from backup.sh` is the command rather than an anchor
+		if count+1 < len(indices) && line[indices[count][0]:indices[count][1]] == "from" {
+			if _, err := time.Parse(time.RFC3339, line[indices[count+1][0]:indices[count+1][1]]); err == nil {
+				count += 2
+			}
+		}
+	case strings.HasPrefix(s, "@"):
+		count += 1
+	case layout == LayoutSixFields:
+		count += 6
+	case layout == LayoutSevenFields:
+		count += 7
+	default:
+		count += 5
+	}
+	if count > len(indices) {
+		return nil, &ParseError{
+			Kind:  ErrorMissingFields,
+			Input: line,
+			Begin: end,
+			End:   end,
+		}
+	}
+
+	l := &Line{
+		ScheduleBegin: indices[0][0],
+		ScheduleEnd:   indices[count-1][1],
+		CommandEnd:    end,
+	}
+	if layout == LayoutSystem && count < len(indices) {
+		l.UserBegin, l.UserEnd = indices[count][0], indices[count][1]
+		l.User = line[l.UserBegin:l.UserEnd]
+		count += 1
+	}
+	if count == len(indices) {
+		return nil, &ParseError{
+			Kind:  ErrorMissingCommand,
+			Input: line,
+			Begin: end,
+			End:   end,
+		}
+	}
+	l.CommandBegin = indices[count][0]
+	l.Command = line[l.CommandBegin:l.CommandEnd]
+
+	expr, err := ParseWithOptions(line[l.ScheduleBegin:l.ScheduleEnd], options...)
+	if err != nil {
+		if perr, ok := err.(*ParseError); ok {
+			perr.Input = line
+			perr.Begin += l.ScheduleBegin
+			perr.End += l.ScheduleBegin
+		}
+		return nil, err
+	}
+	l.Expression = expr
+	return l, nil
+}
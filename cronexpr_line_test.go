@@ -0,0 +1,89 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_line_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+)
+
+/******************************************************************************/
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		layout   LineLayout
+		schedule string
+		user     string
+		command  string
+		canon    string
+	}{
+		{"*/5 * * * * /usr/bin/backup --full", LayoutVixie, "*/5 * * * *", "", "/usr/bin/backup --full", "0 */5 * * * * *"},
+		{"  0 3 * * 1-5\t  echo  'a  b' > /dev/null 2>&1  \n", LayoutVixie, "0 3 * * 1-5", "", "echo  'a  b' > /dev/null 2>&1  ", "0 0 3 * * 1-5 *"},
+		{"17 * * * * root cd / && run-parts --report /etc/cron.hourly\r\n", LayoutSystem, "17 * * * *", "root", "cd / && run-parts --report /etc/cron.hourly", "0 17 * * * * *"},
+		{"0 0 1 1 * 2030 happy-new-year", LayoutSixFields, "0 0 1 1 * 2030", "", "happy-new-year", "0 0 0 1 1 * 2030"},
+		{"30 0 0 * * * * tick", LayoutSevenFields, "30 0 0 * * * *", "", "tick", "30 0 0 * * * *"},
+		{"@daily root logrotate", LayoutSystem, "@daily", "root", "logrotate", "0 0 0 * * * *"},
+		{"@every 90m from 2024-01-01T00:00:00Z sync", LayoutVixie, "@every 90m from 2024-01-01T00:00:00Z", "", "sync", "@every 1h30m0s from 2024-01-01T00:00:00Z"},
+		{"@every 90m sync", LayoutSevenFields, "@every 90m", "", "sync", "@every 1h30m0s"},
+		{"@every 1h from backup.sh", LayoutVixie, "@every 1h", "", "from backup.sh", "@every 1h0m0s"},
+		{"CRON_TZ=UTC 0 9 * * * report", LayoutVixie, "CRON_TZ=UTC 0 9 * * *", "", "report", "CRON_TZ=UTC 0 0 9 * * * *"},
+	}
+	for _, test := range tests {
+		l, err := ParseLine(test.line, test.layout)
+		if err != nil {
+			t.Errorf(`ParseLine("%s") returned "%s"`, test.line, err)
+			continue
+		}
+		schedule := test.line[l.ScheduleBegin:l.ScheduleEnd]
+		user := test.line[l.UserBegin:l.UserEnd]
+		command := test.line[l.CommandBegin:l.CommandEnd]
+		if schedule != test.schedule || user != test.user || l.User != test.user || command != test.command || l.Command != test.command {
+			t.Errorf(`ParseLine("%s") = "%s", "%s", "%s", expected "%s", "%s", "%s"`, test.line, schedule, l.User, l.Command, test.schedule, test.user, test.command)
+		}
+		if canon := l.Expression.String(); canon != test.canon {
+			t.Errorf(`ParseLine("%s").Expression = "%s", expected "%s"`, test.line, canon, test.canon)
+		}
+	}
+
+	// Rewriting a schedule in place
+	line := "*/5 * * * *  /usr/bin/backup --full"
+	l, _ := ParseLine(line, LayoutVixie)
+	if rewritten := line[:l.ScheduleBegin] + "0 3 * * *" + line[l.ScheduleEnd:]; rewritten != "0 3 * * *  /usr/bin/backup --full" {
+		t.Errorf(`rewritten line = "%s"`, rewritten)
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	tests := []struct {
+		line   string
+		layout LineLayout
+		kind   ErrorKind
+		begin  int
+	}{
+		{"", LayoutVixie, ErrorMissingFields, 0},
+		{"*/5 * * *\n", LayoutVixie, ErrorMissingFields, 9},
+		{"*/5 * * * *", LayoutVixie, ErrorMissingCommand, 11},
+		{"*/5 * * * * root", LayoutSystem, ErrorMissingCommand, 16},
+		{"  */5 * 32 * * backup", LayoutVixie, ErrorSyntax, 8},
+		{"0 0 1 1 * 1-2-3 backup", LayoutSixFields, ErrorSyntax, 10},
+	}
+	for _, test := range tests {
+		_, err := ParseLine(test.line, test.layout)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Kind != test.kind || perr.Begin != test.begin || perr.Input != test.line {
+			t.Errorf(`ParseLine("%s") returned "%v", expected %s at %d`, test.line, err, test.kind, test.begin)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_lint.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+/******************************************************************************/
+
+// WarningCode identifies the reason of a Warning. Codes are stable: new codes
+// are added at the end, and the name of a code never changes.
+type WarningCode int
+
+const (
+	// WarningUnevenStep: a step does not evenly divide the range of its
+	// field, hence the field restarts at every period, e.g. `*/7` in the
+	// minute field fires at 56 then at 0, 4 minutes later.
+	WarningUnevenStep WarningCode = iota + 1
+	// WarningDayFieldsOr: both the day-of-month and the day-of-week fields
+	// are restricted, hence a day matching either of them fires, e.g.
+	// `0 0 13 * FRI` fires on every 13th and on every Friday.
+	WarningDayFieldsOr
+	// WarningShortMonths: a day of month does not exist in some of the
+	// months specified, which are skipped, e.g. `31`.
+	WarningShortMonths
+	// WarningDSTGap: an hour specified is skipped on the day daylight-saving
+	// time starts, e.g. 02:30 in Europe or North America.
+	WarningDSTGap
+)
+
+var warningCodeNames = map[WarningCode]string{
+	WarningUnevenStep:  "uneven-step",
+	WarningDayFieldsOr: "day-fields-or",
+	WarningShortMonths: "short-months",
+	WarningDSTGap:      "dst-gap",
+}
+
+// String returns the name of the code, e.g. "uneven-step".
+func (code WarningCode) String() string {
+	if name, ok := warningCodeNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("WarningCode(%d)", int(code))
+}
+
+// A Warning describes a surprising behavior of a well-formed cron expression.
+type Warning struct {
+	Code WarningCode
+	// Field is the name of the affected field, i.e. "second", "minute",
+	// "hour", "day-of-month", "month" or "day-of-week".
+	Field   string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s field: %s (%s)", w.Field, w.Message, w.Code)
+}
+
+/******************************************************************************/
+
+// Lint returns warnings about the surprising behaviors of the cron expression
+// `expr`, if any. Unlike parse errors, warnings do not prevent a cron
+// expression from being used.
+//
+// The hours skipped when daylight-saving time starts are those of the time
+// zone of `expr` in any of the years of its year field, or of 2020 to 2039 if
+// the year field is not restricted, so that the result does not depend on
+// the current date. They are 02:00 to 03:00, as in Europe and North America,
+// if `expr` has no time zone of its own. No WarningDSTGap is returned if a
+// gap policy other than the default one is in effect, see WithDSTPolicy.
+func Lint(expr *Expression) []Warning {
+	var warnings []Warning
+	if expr.members != nil {
+		for _, member := range expr.members {
+			warnings = append(warnings, Lint(member.(*Expression))...)
+		}
+		return warnings
+	}
+	if expr.every > 0 {
+		return nil
+	}
+	warnings = append(warnings, expr.lintSteps()...)
+	if expr.daysOfMonthRestricted && expr.daysOfWeekRestricted && !expr.daysIntersect() {
+		warnings = append(warnings, Warning{
+			Code:    WarningDayFieldsOr,
+			Field:   dowDescriptor.name,
+			Message: "both day fields are restricted, days matching either of them fire",
+		})
+	}
+	warnings = append(warnings, expr.lintShortMonths()...)
+	warnings = append(warnings, expr.lintDSTGap()...)
+	return warnings
+}
+
+/******************************************************************************/
+
+// linted tells what the period after which a field restarts is, e.g. the
+// minute field restarts every hour.
+var linted = []struct {
+	index  int
+	desc   fieldDescriptor
+	period string
+}{
+	{secondIndex, secondDescriptor, "minute"},
+	{minuteIndex, minuteDescriptor, "hour"},
+	{hourIndex, hourDescriptor, "day"},
+	{domIndex, domDescriptor, "month"},
+	{monthIndex, monthDescriptor, "year"},
+	{dowIndex, dowDescriptor, "week"},
+}
+
+func (expr *Expression) lintSteps() []Warning {
+	var warnings []Warning
+	for _, field := range linted {
+		desc := field.desc
+		for _, directive := range expr.directives[field.index] {
+			first, last, step := directive.first, directive.last, directive.step
+			if directive.kind != span || step < 2 || last != desc.max || first > last {
+				continue
+			}
+			// Months are 28 to 31 days long, no step fits them all
+			if desc.name != domDescriptor.name && (desc.max-desc.min+1)%step == 0 {
+				continue
+			}
+			lastValue := first + (last-first)/step*step
+			warnings = append(warnings, Warning{
+				Code:    WarningUnevenStep,
+				Field:   desc.name,
+				Message: fmt.Sprintf("a step of %d restarts at %d every %s, %d is followed by %d", step, first, field.period, lastValue, first),
+			})
+		}
+	}
+	return warnings
+}
+
+// lintShortMonths warns about the days of month which are skipped in some of
+// the months specified.
+func (expr *Expression) lintShortMonths() []Warning {
+	if !expr.daysOfMonthRestricted {
+		return nil
+	}
+	var warnings []Warning
+	lint := func(day int, directive string) {
+		var skipped []string
+		for _, month := range expr.monthList {
+			switch {
+			case day == 29 && month == 2 && !expr.leapYearsOnly():
+				skipped = append(skipped, English.MonthName(time.February)+" of common years")
+			case day > time.Date(2000, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day():
+				skipped = append(skipped, English.MonthName(time.Month(month)))
+			}
+		}
+		if len(skipped) > 0 {
+			warnings = append(warnings, Warning{
+				Code:    WarningShortMonths,
+				Field:   domDescriptor.name,
+				Message: fmt.Sprintf("%s is skipped in %s", directive, English.joinList(skipped)),
+			})
+		}
+	}
+	for _, day := range toList(expr.daysOfMonth) {
+		lint(day, strconv.Itoa(day))
+	}
+	for _, day := range toList(expr.workdaysOfMonth) {
+		lint(day, strconv.Itoa(day)+"W")
+	}
+	return warnings
+}
+
+// lintDSTGap warns about the hours specified which are skipped when
+// daylight-saving time starts.
+func (expr *Expression) lintDSTGap() []Warning {
+	if expr.dstGap != DSTGapShift || len(expr.hourList) == len(hourDescriptor.defaultList) {
+		return nil
+	}
+	gap := map[int]bool{2: true}
+	where := "in Europe and North America"
+	if expr.location != nil {
+		years := expr.yearList
+		if years == nil {
+			years = lintYears
+		}
+		gap = make(map[int]bool)
+		for _, year := range years {
+			for hour := range dstGapHours(expr.location, year) {
+				gap[hour] = true
+			}
+		}
+		where = "in " + expr.location.String()
+	}
+	var hours []string
+	for _, hour := range expr.hourList {
+		if gap[hour] {
+			hours = append(hours, twoDigits(hour)+":xx")
+		}
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+	return []Warning{{
+		Code:    WarningDSTGap,
+		Field:   hourDescriptor.name,
+		Message: fmt.Sprintf("%s does not exist on the day daylight-saving time starts %s", English.joinList(hours), where),
+	}}
+}
+
+// lintYears are the years whose daylight-saving time gaps are linted when the
+// year field is not restricted.
+var lintYears = intRange(2020, 2039)
+
+// dstGapHours returns the local hours of `year` which are skipped, wholly or
+// partly, in `loc`, e.g. by the 30 minute gaps of Australia/Lord_Howe. Only
+// the days around a change of UTC offset are scanned quarter by quarter.
+func dstGapHours(loc *time.Location, year int) map[int]bool {
+	hours := make(map[int]bool)
+	prev := time.Date(year, time.January, 0, 12, 0, 0, 0, loc)
+	for day := prev.AddDate(0, 0, 1); day.Year() == year; prev, day = day, day.AddDate(0, 0, 1) {
+		_, prevOffset := prev.Zone()
+		if _, offset := day.Zone(); offset == prevOffset {
+			continue
+		}
+		for _, d := range []time.Time{prev, day} {
+			for hour := 0; hour < 24; hour++ {
+				for minute := 0; minute < 60; minute += 15 {
+					t := time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, loc)
+					if t.Hour() != hour || t.Minute() != minute {
+						hours[hour] = true
+					}
+				}
+			}
+		}
+	}
+	return hours
+}
+
+// leapYearsOnly tells whether the year field lists leap years only.
+func (expr *Expression) leapYearsOnly() bool {
+	if expr.yearList == nil {
+		return false
+	}
+	for _, year := range expr.yearList {
+		if time.Date(year, time.February, 29, 0, 0, 0, 0, time.UTC).Day() != 29 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_lint_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+)
+
+/******************************************************************************/
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{"*/15 * * * *", nil},
+		{"5/20 * * * *", nil},
+		{"@every 7m", nil},
+		{"*/7 * * * *", []string{
+			"minute field: a step of 7 restarts at 0 every hour, 56 is followed by 0 (uneven-step)",
+		}},
+		{"0 0 * * */2", []string{
+			"day-of-week field: a step of 2 restarts at 0 every week, 6 is followed by 0 (uneven-step)",
+		}},
+		{"0 0 13 * FRI", []string{
+			"day-of-week field: both day fields are restricted, days matching either of them fire (day-fields-or)",
+		}},
+		{"0 0 31 * *", []string{
+			"day-of-month field: 31 is skipped in February, April, June, September and November (short-months)",
+		}},
+		{"0 0 29,31W 1-3 *", []string{
+			"day-of-month field: 29 is skipped in February of common years (short-months)",
+			"day-of-month field: 31W is skipped in February (short-months)",
+		}},
+		{"0 0 31 1,3 *", nil},
+		{"0 0 29 2 * 2024-2096/4", nil},
+		// 2100 is a common year
+		{"0 0 29 2 * 2024/4", []string{
+			"day-of-month field: 29 is skipped in February of common years (short-months)",
+		}},
+		{"0 0 29 2 * 2024-2025", []string{
+			"day-of-month field: 29 is skipped in February of common years (short-months)",
+		}},
+		{"30 2 * * *", []string{
+			"hour field: 02:xx does not exist on the day daylight-saving time starts in Europe and North America (dst-gap)",
+		}},
+		{"CRON_TZ=Europe/Paris 30 1-3 * * *", []string{
+			"hour field: 02:xx does not exist on the day daylight-saving time starts in Europe/Paris (dst-gap)",
+		}},
+		{"CRON_TZ=UTC 30 2 * * *", nil},
+		// 02:00 -> 02:30
+		{"CRON_TZ=Australia/Lord_Howe 0 2 * * *", []string{
+			"hour field: 02:xx does not exist on the day daylight-saving time starts in Australia/Lord_Howe (dst-gap)",
+		}},
+		{"CRON_TZ=Europe/Paris 30 2 * * * 1970", nil},
+		{"0 * * * *", nil},
+		{"*/7 * * * * | 0 0 13 * FRI", []string{
+			"minute field: a step of 7 restarts at 0 every hour, 56 is followed by 0 (uneven-step)",
+			"day-of-week field: both day fields are restricted, days matching either of them fire (day-fields-or)",
+		}},
+	}
+	for _, test := range tests {
+		var actual []string
+		for _, warning := range Lint(MustParse(test.expr)) {
+			actual = append(actual, warning.String())
+		}
+		if !equalStrings(actual, test.expected) {
+			t.Errorf(`Lint("%s") = %q, expected %q`, test.expr, actual, test.expected)
+		}
+	}
+
+	// Deliberate policies are not linted
+	expr, _ := ParseWithOptions("30 2 * * *", WithDSTPolicy(DSTGapSkip, DSTOverlapOnce))
+	if warnings := Lint(expr); len(warnings) != 0 {
+		t.Errorf(`Lint("30 2 * * *") = %v with a DST policy, expected none`, warnings)
+	}
+	expr, _ = ParseWithOptions("0 0 13 * FRI", WithDialect(DialectQuartz))
+	if warnings := Lint(expr); len(warnings) != 0 {
+		t.Errorf(`Lint("0 0 13 * FRI") = %v with the Quartz dialect, expected none`, warnings)
+	}
+}
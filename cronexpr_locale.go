@@ -0,0 +1,629 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_locale.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/******************************************************************************/
+
+// A Locale holds what it takes to describe cron expressions in a given
+// language, see DescribeIn().
+//
+// Messages are `fmt` formats keyed by message ID, see the English locale for
+// the list of message IDs and their arguments. A message may have several
+// plural forms separated by `|`, selected by the Plural rule of the locale.
+// Messages missing from a locale fall back to English.
+type Locale struct {
+	// Tag identifies the locale, e.g. `fr` or `pt-BR`, see LookupLocale().
+	Tag string
+	// Months holds the names of the months, January first.
+	Months [12]string
+	// Weekdays holds the names of the days of the week, Sunday first.
+	Weekdays [7]string
+	// Messages is the message catalogue of the locale.
+	Messages map[string]string
+	// Plural returns the index of the plural form to use for a count of `n`,
+	// nil for English rules.
+	Plural func(n int) int
+	// Ordinal formats day of month `n`, e.g. `15th`, nil for English rules.
+	Ordinal func(n int) string
+}
+
+// MonthName returns the name of month `m` in the language of the locale.
+func (l *Locale) MonthName(m time.Month) string {
+	return l.Months[(int(m)+11)%12]
+}
+
+// WeekdayName returns the name of day of week `d` in the language of the
+// locale.
+func (l *Locale) WeekdayName(d time.Weekday) string {
+	return l.Weekdays[(int(d)%7+7)%7]
+}
+
+// message returns the form of message `id` for a count of `n`.
+func (l *Locale) message(id string, n int) string {
+	format, ok := l.Messages[id]
+	plural := l.Plural
+	if !ok || plural == nil {
+		plural = englishPlural
+	}
+	if !ok {
+		format = English.Messages[id]
+	}
+	forms := strings.Split(format, "|")
+	i := plural(n)
+	if i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return forms[i]
+}
+
+func (l *Locale) ordinal(n int) string {
+	if l.Ordinal == nil {
+		return englishOrdinal(n)
+	}
+	return l.Ordinal(n)
+}
+
+/******************************************************************************/
+
+var (
+	localeRegistry     = make(map[string]*Locale)
+	localeRegistryLock sync.RWMutex
+)
+
+func init() {
+	for _, l := range []*Locale{English, French, German, Spanish, Italian} {
+		RegisterLocale(l)
+	}
+}
+
+// RegisterLocale makes locale `l` available to LookupLocale() under its Tag,
+// replacing any locale previously registered under the same Tag.
+func RegisterLocale(l *Locale) {
+	localeRegistryLock.Lock()
+	defer localeRegistryLock.Unlock()
+	localeRegistry[strings.ToLower(l.Tag)] = l
+}
+
+// LookupLocale returns the locale registered under `tag`, or else under its
+// base language, e.g. `fr` for `fr-CA`. It returns nil if there is none.
+func LookupLocale(tag string) *Locale {
+	localeRegistryLock.RLock()
+	defer localeRegistryLock.RUnlock()
+	tag = strings.ToLower(strings.Replace(tag, "_", "-", -1))
+	if l, ok := localeRegistry[tag]; ok {
+		return l
+	}
+	if i := strings.IndexByte(tag, '-'); i > 0 {
+		return localeRegistry[tag[:i]]
+	}
+	return nil
+}
+
+/******************************************************************************/
+
+// ParseWithLocale is like Parse, except that month and day-of-week names are
+// those of `locale`, see WithLocale.
+func ParseWithLocale(cronLine string, locale *Locale) (*Expression, error) {
+	return ParseWithOptions(cronLine, WithLocale(locale))
+}
+
+// WithLocale causes month and day-of-week names to be those of `locale`
+// instead of English, e.g. `lun-ven` with French or `Mo-Fr` with German.
+//
+// Names are case-insensitive, accents are optional, and any prefix of at
+// least two letters is accepted provided it is not ambiguous: `ma` is
+// rejected with French since it may stand for `mars` or `mai`. Numeric values
+// are still accepted, English names are not.
+//
+// A nil locale, or English, keeps the default behavior, that is, English
+// names or their first three letters only. The locale must not be modified
+// once used.
+func WithLocale(locale *Locale) Option {
+	return func(opts *parseOptions) {
+		opts.names = locale
+		if locale == English {
+			opts.names = nil
+		}
+	}
+}
+
+var (
+	nameFinder = regexp.MustCompile(`\pL+`)
+	// Letters with diacritics are matched as if they had none
+	nameFolder = strings.NewReplacer(
+		"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a",
+		"ç", "c",
+		"è", "e", "é", "e", "ê", "e", "ë", "e",
+		"ì", "i", "í", "i", "î", "i", "ï", "i",
+		"ñ", "n",
+		"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ù", "u", "ú", "u", "û", "u", "ü", "u",
+		"ß", "ss",
+	)
+	namesDescriptors     = make(map[*Locale][2]fieldDescriptor)
+	namesDescriptorsLock sync.Mutex
+)
+
+// namesDescriptor returns the descriptor of the month or day-of-week field
+// `desc` for the names the cron expression is parsed with.
+func (expr *Expression) namesDescriptor(desc fieldDescriptor) fieldDescriptor {
+	if expr.names == nil {
+		return desc
+	}
+	namesDescriptorsLock.Lock()
+	defer namesDescriptorsLock.Unlock()
+	descs, ok := namesDescriptors[expr.names]
+	if !ok {
+		descs[0] = localizeDescriptor(monthDescriptor, `0?[1-9]|1[012]`, expr.names.Months[:], 1)
+		descs[1] = localizeDescriptor(dowDescriptor, `0?[0-7]`, expr.names.Weekdays[:], 0)
+		namesDescriptors[expr.names] = descs
+	}
+	if desc.name == monthDescriptor.name {
+		return descs[0]
+	}
+	return descs[1]
+}
+
+// localizeDescriptor returns a copy of descriptor `desc` accepting `names`,
+// whose values start at `first`, along with their unambiguous prefixes.
+func localizeDescriptor(desc fieldDescriptor, numberPattern string, names []string, first int) fieldDescriptor {
+	values := make(map[string]map[int]bool)
+	exact := make(map[string]int)
+	for i, name := range names {
+		name = strings.ToLower(name)
+		for _, form := range []string{name, nameFolder.Replace(name)} {
+			exact[form] = first + i
+			runes := []rune(form)
+			for n := 2; n <= len(runes); n++ {
+				prefix := string(runes[:n])
+				if values[prefix] == nil {
+					values[prefix] = make(map[int]bool)
+				}
+				values[prefix][first+i] = true
+			}
+		}
+	}
+	tokens := make(map[string]int)
+	ambiguous := make(map[string]bool)
+	for prefix, set := range values {
+		if v, ok := exact[prefix]; ok {
+			tokens[prefix] = v
+		} else if len(set) == 1 {
+			for v := range set {
+				tokens[prefix] = v
+			}
+		} else {
+			ambiguous[prefix] = true
+		}
+	}
+	// Longest tokens first
+	alternatives := make([]string, 0, len(tokens))
+	for token := range tokens {
+		alternatives = append(alternatives, regexp.QuoteMeta(token))
+	}
+	sort.Slice(alternatives, func(i, j int) bool {
+		if len(alternatives[i]) != len(alternatives[j]) {
+			return len(alternatives[i]) > len(alternatives[j])
+		}
+		return alternatives[i] < alternatives[j]
+	})
+	numeric := desc.atoi
+	desc.valuePattern = numberPattern + "|" + strings.Join(alternatives, "|")
+	desc.atoi = func(s string) int {
+		if v, ok := tokens[s]; ok {
+			return v
+		}
+		return numeric(s)
+	}
+	desc.ambiguous = func(s string) bool {
+		return ambiguous[nameFolder.Replace(s)]
+	}
+	return desc
+}
+
+/******************************************************************************/
+
+func englishPlural(n int) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
+
+func englishOrdinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// English is the default locale, it also documents message IDs and their
+// arguments.
+var English = &Locale{
+	Tag:      "en",
+	Months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	Messages: map[string]string{
+		// Directives of each field, where `%s` stands for values and `%d`
+		// for the step of `*/2`, `5/2` and `5-20/2`. `.one` messages are
+		// passed the list of single values and their count.
+		"second.every":    "every second",
+		"second.one":      "at second %s|at seconds %s",
+		"second.span":     "seconds %s through %s",
+		"second.step":     "every %d seconds",
+		"second.stepFrom": "every %d seconds starting at second %s",
+		"second.stepSpan": "every %d seconds, seconds %s through %s",
+		"minute.every":    "every minute",
+		"minute.one":      "at minute %s|at minutes %s",
+		"minute.span":     "minutes %s through %s",
+		"minute.step":     "every %d minutes",
+		"minute.stepFrom": "every %d minutes starting at minute %s",
+		"minute.stepSpan": "every %d minutes, minutes %s through %s",
+		"hour.every":      "every hour",
+		"hour.one":        "during hour %s|during hours %s",
+		"hour.span":       "between %s:00 and %s:59",
+		"hour.step":       "every %d hours",
+		"hour.stepFrom":   "every %d hours starting at %s:00",
+		"hour.stepSpan":   "every %d hours between %s:00 and %s:59",
+		"dom.every":       "every day",
+		"dom.one":         "on the %s",
+		"dom.span":        "on the %s through the %s",
+		"dom.step":        "every %d days",
+		"dom.stepFrom":    "every %d days starting on the %s",
+		"dom.stepSpan":    "every %d days from the %s through the %s",
+		"dom.last":        "on the last day of the month",
+		"dom.lastWeekday": "on the last weekday of the month",
+		"dom.nearest":     "on the weekday nearest the %s",
+		"month.every":     "every month",
+		"month.one":       "in %s",
+		"month.span":      "%s through %s",
+		"month.step":      "every %d months",
+		"month.stepFrom":  "every %d months starting in %s",
+		"month.stepSpan":  "every %d months from %s through %s",
+		"dow.every":       "every day",
+		"dow.one":         "on %s",
+		"dow.span":        "%s through %s",
+		"dow.step":        "every %d days of the week",
+		"dow.stepFrom":    "every %d days of the week starting on %s",
+		"dow.stepSpan":    "every %d days of the week from %s through %s",
+		"dow.last":        "on the last %s of the month",
+		"dow.nth":         "on the %s %s of the month", // week, day of week
+		"year.every":      "every year",
+		"year.one":        "in %s",
+		"year.span":       "%s through %s",
+		"year.step":       "every %d years",
+		"year.stepFrom":   "every %d years starting in %s",
+		"year.stepSpan":   "every %d years from %s through %s",
+		// Week of `5#3`
+		"week.1": "first",
+		"week.2": "second",
+		"week.3": "third",
+		"week.4": "fourth",
+		"week.5": "fifth",
+		// Times of day, e.g. `03:30`
+		"at": "at %s",
+		// `@every` duration, and its anchor
+		"every":     "every %s",
+		"everyFrom": "every %s starting at %s",
+		// Name of a time zone
+		"timeZone": "in time zone %s",
+		// Last item of a list, and day-of-month combined with day-of-week
+		"and": " and ",
+		"or":  " or ",
+	},
+}
+
+// French is the French locale.
+var French = &Locale{
+	Tag:      "fr",
+	Months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	Weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	Messages: map[string]string{
+		"second.every":    "chaque seconde",
+		"second.one":      "à la seconde %s|aux secondes %s",
+		"second.span":     "des secondes %s à %s",
+		"second.step":     "toutes les %d secondes",
+		"second.stepFrom": "toutes les %d secondes à partir de la seconde %s",
+		"second.stepSpan": "toutes les %d secondes, des secondes %s à %s",
+		"minute.every":    "chaque minute",
+		"minute.one":      "à la minute %s|aux minutes %s",
+		"minute.span":     "des minutes %s à %s",
+		"minute.step":     "toutes les %d minutes",
+		"minute.stepFrom": "toutes les %d minutes à partir de la minute %s",
+		"minute.stepSpan": "toutes les %d minutes, des minutes %s à %s",
+		"hour.every":      "chaque heure",
+		"hour.one":        "pendant l'heure %s|pendant les heures %s",
+		"hour.span":       "entre %s:00 et %s:59",
+		"hour.step":       "toutes les %d heures",
+		"hour.stepFrom":   "toutes les %d heures à partir de %s:00",
+		"hour.stepSpan":   "toutes les %d heures entre %s:00 et %s:59",
+		"dom.every":       "chaque jour",
+		"dom.one":         "le %s|les %s",
+		"dom.span":        "du %s au %s",
+		"dom.step":        "tous les %d jours",
+		"dom.stepFrom":    "tous les %d jours à partir du %s",
+		"dom.stepSpan":    "tous les %d jours du %s au %s",
+		"dom.last":        "le dernier jour du mois",
+		"dom.lastWeekday": "le dernier jour ouvré du mois",
+		"dom.nearest":     "le jour ouvré le plus proche du %s",
+		"month.every":     "chaque mois",
+		"month.one":       "en %s",
+		"month.span":      "de %s à %s",
+		"month.step":      "tous les %d mois",
+		"month.stepFrom":  "tous les %d mois à partir de %s",
+		"month.stepSpan":  "tous les %d mois de %s à %s",
+		"dow.every":       "chaque jour",
+		"dow.one":         "le %s|les %s",
+		"dow.span":        "du %s au %s",
+		"dow.step":        "tous les %d jours de la semaine",
+		"dow.stepFrom":    "tous les %d jours de la semaine à partir du %s",
+		"dow.stepSpan":    "tous les %d jours de la semaine du %s au %s",
+		"dow.last":        "le dernier %s du mois",
+		"dow.nth":         "le %s %s du mois",
+		"year.every":      "chaque année",
+		"year.one":        "en %s",
+		"year.span":       "de %s à %s",
+		"year.step":       "tous les %d ans",
+		"year.stepFrom":   "tous les %d ans à partir de %s",
+		"year.stepSpan":   "tous les %d ans de %s à %s",
+		"week.1":          "premier",
+		"week.2":          "deuxième",
+		"week.3":          "troisième",
+		"week.4":          "quatrième",
+		"week.5":          "cinquième",
+		"at":              "à %s",
+		"every":           "toutes les %s",
+		"everyFrom":       "toutes les %s à partir du %s",
+		"timeZone":        "dans le fuseau horaire %s",
+		"and":             " et ",
+		"or":              " ou ",
+	},
+	// 0 and 1 are singular
+	Plural: func(n int) int {
+		if n <= 1 {
+			return 0
+		}
+		return 1
+	},
+	Ordinal: func(n int) string {
+		if n == 1 {
+			return "1er"
+		}
+		return strconv.Itoa(n)
+	},
+}
+
+// German is the German locale.
+var German = &Locale{
+	Tag:      "de",
+	Months:   [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+	Weekdays: [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	Messages: map[string]string{
+		"second.every":    "jede Sekunde",
+		"second.one":      "in Sekunde %s|in den Sekunden %s",
+		"second.span":     "Sekunden %s bis %s",
+		"second.step":     "alle %d Sekunden",
+		"second.stepFrom": "alle %d Sekunden ab Sekunde %s",
+		"second.stepSpan": "alle %d Sekunden, Sekunden %s bis %s",
+		"minute.every":    "jede Minute",
+		"minute.one":      "in Minute %s|in den Minuten %s",
+		"minute.span":     "Minuten %s bis %s",
+		"minute.step":     "alle %d Minuten",
+		"minute.stepFrom": "alle %d Minuten ab Minute %s",
+		"minute.stepSpan": "alle %d Minuten, Minuten %s bis %s",
+		"hour.every":      "jede Stunde",
+		"hour.one":        "in der Stunde %s|in den Stunden %s",
+		"hour.span":       "zwischen %s:00 und %s:59",
+		"hour.step":       "alle %d Stunden",
+		"hour.stepFrom":   "alle %d Stunden ab %s:00",
+		"hour.stepSpan":   "alle %d Stunden zwischen %s:00 und %s:59",
+		"dom.every":       "jeden Tag",
+		"dom.one":         "am %s",
+		"dom.span":        "vom %s bis %s",
+		"dom.step":        "alle %d Tage",
+		"dom.stepFrom":    "alle %d Tage ab dem %s",
+		"dom.stepSpan":    "alle %d Tage vom %s bis %s",
+		"dom.last":        "am letzten Tag des Monats",
+		"dom.lastWeekday": "am letzten Werktag des Monats",
+		"dom.nearest":     "am nächsten Werktag zum %s",
+		"month.every":     "jeden Monat",
+		"month.one":       "im %s",
+		"month.span":      "von %s bis %s",
+		"month.step":      "alle %d Monate",
+		"month.stepFrom":  "alle %d Monate ab %s",
+		"month.stepSpan":  "alle %d Monate von %s bis %s",
+		"dow.every":       "jeden Tag",
+		"dow.one":         "am %s",
+		"dow.span":        "%s bis %s",
+		"dow.step":        "alle %d Wochentage",
+		"dow.stepFrom":    "alle %d Wochentage ab %s",
+		"dow.stepSpan":    "alle %d Wochentage von %s bis %s",
+		"dow.last":        "am letzten %s des Monats",
+		"dow.nth":         "am %s %s des Monats",
+		"year.every":      "jedes Jahr",
+		"year.one":        "im Jahr %s|in den Jahren %s",
+		"year.span":       "von %s bis %s",
+		"year.step":       "alle %d Jahre",
+		"year.stepFrom":   "alle %d Jahre ab %s",
+		"year.stepSpan":   "alle %d Jahre von %s bis %s",
+		"week.1":          "ersten",
+		"week.2":          "zweiten",
+		"week.3":          "dritten",
+		"week.4":          "vierten",
+		"week.5":          "fünften",
+		"at":              "um %s",
+		"every":           "alle %s",
+		"everyFrom":       "alle %s ab %s",
+		"timeZone":        "in der Zeitzone %s",
+		"and":             " und ",
+		"or":              " oder ",
+	},
+	Ordinal: func(n int) string {
+		return strconv.Itoa(n) + "."
+	},
+}
+
+// Spanish is the Spanish locale.
+var Spanish = &Locale{
+	Tag:      "es",
+	Months:   [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	Weekdays: [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	Messages: map[string]string{
+		"second.every":    "cada segundo",
+		"second.one":      "en el segundo %s|en los segundos %s",
+		"second.span":     "de los segundos %s a %s",
+		"second.step":     "cada %d segundos",
+		"second.stepFrom": "cada %d segundos a partir del segundo %s",
+		"second.stepSpan": "cada %d segundos, de los segundos %s a %s",
+		"minute.every":    "cada minuto",
+		"minute.one":      "en el minuto %s|en los minutos %s",
+		"minute.span":     "de los minutos %s a %s",
+		"minute.step":     "cada %d minutos",
+		"minute.stepFrom": "cada %d minutos a partir del minuto %s",
+		"minute.stepSpan": "cada %d minutos, de los minutos %s a %s",
+		"hour.every":      "cada hora",
+		"hour.one":        "durante la hora %s|durante las horas %s",
+		"hour.span":       "entre las %s:00 y las %s:59",
+		"hour.step":       "cada %d horas",
+		"hour.stepFrom":   "cada %d horas a partir de las %s:00",
+		"hour.stepSpan":   "cada %d horas entre las %s:00 y las %s:59",
+		"dom.every":       "cada día",
+		"dom.one":         "el día %s|los días %s",
+		"dom.span":        "del día %s al %s",
+		"dom.step":        "cada %d días",
+		"dom.stepFrom":    "cada %d días a partir del día %s",
+		"dom.stepSpan":    "cada %d días del día %s al %s",
+		"dom.last":        "el último día del mes",
+		"dom.lastWeekday": "el último día laborable del mes",
+		"dom.nearest":     "el día laborable más cercano al día %s",
+		"month.every":     "cada mes",
+		"month.one":       "en %s",
+		"month.span":      "de %s a %s",
+		"month.step":      "cada %d meses",
+		"month.stepFrom":  "cada %d meses a partir de %s",
+		"month.stepSpan":  "cada %d meses de %s a %s",
+		"dow.every":       "cada día",
+		"dow.one":         "el %s|los %s",
+		"dow.span":        "de %s a %s",
+		"dow.step":        "cada %d días de la semana",
+		"dow.stepFrom":    "cada %d días de la semana a partir del %s",
+		"dow.stepSpan":    "cada %d días de la semana del %s al %s",
+		"dow.last":        "el último %s del mes",
+		"dow.nth":         "el %s %s del mes",
+		"year.every":      "cada año",
+		"year.one":        "en %s",
+		"year.span":       "de %s a %s",
+		"year.step":       "cada %d años",
+		"year.stepFrom":   "cada %d años a partir de %s",
+		"year.stepSpan":   "cada %d años de %s a %s",
+		"week.1":          "primer",
+		"week.2":          "segundo",
+		"week.3":          "tercer",
+		"week.4":          "cuarto",
+		"week.5":          "quinto",
+		"at":              "a las %s",
+		"every":           "cada %s",
+		"everyFrom":       "cada %s a partir del %s",
+		"timeZone":        "en la zona horaria %s",
+		"and":             " y ",
+		"or":              " o ",
+	},
+	Ordinal: strconv.Itoa,
+}
+
+// Italian is the Italian locale.
+var Italian = &Locale{
+	Tag:      "it",
+	Months:   [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+	Weekdays: [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	Messages: map[string]string{
+		"second.every":    "ogni secondo",
+		"second.one":      "al secondo %s|ai secondi %s",
+		"second.span":     "dal secondo %s al %s",
+		"second.step":     "ogni %d secondi",
+		"second.stepFrom": "ogni %d secondi a partire dal secondo %s",
+		"second.stepSpan": "ogni %d secondi, dal secondo %s al %s",
+		"minute.every":    "ogni minuto",
+		"minute.one":      "al minuto %s|ai minuti %s",
+		"minute.span":     "dal minuto %s al %s",
+		"minute.step":     "ogni %d minuti",
+		"minute.stepFrom": "ogni %d minuti a partire dal minuto %s",
+		"minute.stepSpan": "ogni %d minuti, dal minuto %s al %s",
+		"hour.every":      "ogni ora",
+		"hour.one":        "durante l'ora %s|durante le ore %s",
+		"hour.span":       "tra le %s:00 e le %s:59",
+		"hour.step":       "ogni %d ore",
+		"hour.stepFrom":   "ogni %d ore a partire dalle %s:00",
+		"hour.stepSpan":   "ogni %d ore tra le %s:00 e le %s:59",
+		"dom.every":       "ogni giorno",
+		"dom.one":         "il giorno %s|i giorni %s",
+		"dom.span":        "dal giorno %s al %s",
+		"dom.step":        "ogni %d giorni",
+		"dom.stepFrom":    "ogni %d giorni a partire dal giorno %s",
+		"dom.stepSpan":    "ogni %d giorni dal giorno %s al %s",
+		"dom.last":        "l'ultimo giorno del mese",
+		"dom.lastWeekday": "l'ultimo giorno lavorativo del mese",
+		"dom.nearest":     "il giorno lavorativo più vicino al giorno %s",
+		"month.every":     "ogni mese",
+		"month.one":       "a %s",
+		"month.span":      "da %s a %s",
+		"month.step":      "ogni %d mesi",
+		"month.stepFrom":  "ogni %d mesi a partire da %s",
+		"month.stepSpan":  "ogni %d mesi da %s a %s",
+		"dow.every":       "ogni giorno",
+		"dow.one":         "di %s",
+		"dow.span":        "da %s a %s",
+		"dow.step":        "ogni %d giorni della settimana",
+		"dow.stepFrom":    "ogni %d giorni della settimana a partire da %s",
+		"dow.stepSpan":    "ogni %d giorni della settimana da %s a %s",
+		"dow.last":        "l'ultimo %s del mese",
+		"dow.nth":         "il %s %s del mese",
+		"year.every":      "ogni anno",
+		"year.one":        "nel %s|negli anni %s",
+		"year.span":       "dal %s al %s",
+		"year.step":       "ogni %d anni",
+		"year.stepFrom":   "ogni %d anni a partire dal %s",
+		"year.stepSpan":   "ogni %d anni dal %s al %s",
+		"week.1":          "primo",
+		"week.2":          "secondo",
+		"week.3":          "terzo",
+		"week.4":          "quarto",
+		"week.5":          "quinto",
+		"at":              "alle %s",
+		"every":           "ogni %s",
+		"everyFrom":       "ogni %s a partire dal %s",
+		"timeZone":        "nel fuso orario %s",
+		"and":             " e ",
+		"or":              " o ",
+	},
+	Ordinal: strconv.Itoa,
+}
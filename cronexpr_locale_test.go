@@ -0,0 +1,152 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_locale_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+var localeDescribeTests = []struct {
+	locale   *Locale
+	expr     string
+	expected string
+}{
+	{French, "0 30 3 15W 3/3 * *", "À 03:30, le jour ouvré le plus proche du 15, tous les 3 mois à partir de mars"},
+	{French, "*/15 9-17 * * 1-5", "Toutes les 15 minutes, entre 09:00 et 17:59, du lundi au vendredi"},
+	{French, "0 0 1,15,L * *", "À 00:00, les 1er et 15 et le dernier jour du mois"},
+	{French, "5 * * * *", "À la minute 5"},
+	{French, "5,10 * * * *", "Aux minutes 5 et 10"},
+	{German, "0 30 3 15W 3/3 * *", "Um 03:30, am nächsten Werktag zum 15., alle 3 Monate ab März"},
+	{German, "0 0 * * MON#3", "Um 00:00, am dritten Montag des Monats"},
+	{German, "0 0 13 * FRI", "Um 00:00, am 13. oder am Freitag"},
+	{Spanish, "0 30 3 15W 3/3 * *", "A las 03:30, el día laborable más cercano al día 15, cada 3 meses a partir de marzo"},
+	{Spanish, "0 0 1 1 * 2025-2031/2", "A las 00:00, el día 1, en enero, cada 2 años de 2025 a 2031"},
+	{Spanish, "CRON_TZ=Europe/Madrid 0 9 * * 5L", "A las 09:00, el último viernes del mes, en la zona horaria Europe/Madrid"},
+	{Italian, "0 0 * * MON#3", "Alle 00:00, il terzo lunedì del mese"},
+	{nil, "0 9 * * 1-5", "At 09:00, Monday through Friday"},
+}
+
+func TestDescribeIn(t *testing.T) {
+	for _, test := range localeDescribeTests {
+		if s := MustParse(test.expr).DescribeIn(test.locale); s != test.expected {
+			t.Errorf(`("%s").DescribeIn() = "%s", expected "%s"`, test.expr, s, test.expected)
+		}
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected *Locale
+	}{
+		{"en", English},
+		{"FR", French},
+		{"fr-CA", French},
+		{"de_AT", German},
+		{"es", Spanish},
+		{"it-IT", Italian},
+		{"pt-BR", nil},
+	}
+	for _, test := range tests {
+		if l := LookupLocale(test.tag); l != test.expected {
+			t.Errorf(`LookupLocale("%s") = %v, expected %v`, test.tag, l, test.expected)
+		}
+	}
+	if name := French.MonthName(time.August); name != "août" {
+		t.Errorf(`MonthName() = "%s"`, name)
+	}
+	if name := German.WeekdayName(time.Sunday); name != "Sonntag" {
+		t.Errorf(`WeekdayName() = "%s"`, name)
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	// A partial locale: missing messages fall back to English
+	RegisterLocale(&Locale{
+		Tag:      "ja",
+		Months:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		Weekdays: [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		Messages: map[string]string{
+			"at":      "%s",
+			"and":     "と",
+			"dow.one": "%s",
+		},
+		Plural:  func(n int) int { return 0 },
+		Ordinal: func(n int) string { return strconv.Itoa(n) + "日" },
+	})
+	l := LookupLocale("ja-JP")
+	if l == nil {
+		t.Fatal(`LookupLocale("ja-JP") returned nil`)
+	}
+	expected := "09:00, 月曜日と金曜日, every 2 months starting in 2月"
+	if s := MustParse("0 9 * 2/2 MON,FRI").DescribeIn(l); s != expected {
+		t.Errorf(`DescribeIn() = "%s", expected "%s"`, s, expected)
+	}
+}
+
+/******************************************************************************/
+
+func TestParseWithLocale(t *testing.T) {
+	tests := []struct {
+		locale   *Locale
+		expr     string
+		expected string
+	}{
+		{French, "0 9 * * lun-ven", "0 0 9 * * 1-5 *"},
+		{French, "0 9 * juil,AOÛ,aou,déc *", "0 0 9 * 7,8,12 * *"},
+		{German, "0 9 * * Mo-Fr", "0 0 9 * * 1-5 *"},
+		{German, "0 9 1 MÄR,Mar,Mai *", "0 0 9 1 3,5 * *"},
+		{Spanish, "0 9 * * mié,MIE,sábado", "0 0 9 * * 3,6 *"},
+		{Italian, "0 9 * * venL,lun#2", "0 0 9 * * 5L,1#2 *"},
+		{Italian, "0 9 1 gen-giu/2 *", "0 0 9 1 1-5/2 * *"},
+		{English, "0 9 * * mon-fri", "0 0 9 * * 1-5 *"},
+		{nil, "0 9 * JAN * ", "0 0 9 * 1 * *"},
+	}
+	for _, test := range tests {
+		expr, err := ParseWithLocale(test.expr, test.locale)
+		if err != nil {
+			t.Errorf(`ParseWithLocale("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		if s := expr.String(); s != test.expected {
+			t.Errorf(`ParseWithLocale("%s").String() = "%s", expected "%s"`, test.expr, s, test.expected)
+		}
+	}
+
+	errorTests := []struct {
+		locale *Locale
+		expr   string
+		kind   ErrorKind
+		begin  int
+	}{
+		{French, "0 9 * ma *", ErrorAmbiguousName, 6},
+		{German, "0 9 * Ju-Dez *", ErrorAmbiguousName, 6},
+		{Italian, "0 9 * * lun,m", ErrorSyntax, 12},
+		{Italian, "0 9 1 ma *", ErrorAmbiguousName, 6},
+		{French, "0 9 * * mon", ErrorSyntax, 8},
+		{English, "0 9 * * mo", ErrorSyntax, 8},
+	}
+	for _, test := range errorTests {
+		_, err := ParseWithLocale(test.expr, test.locale)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Kind != test.kind || perr.Begin != test.begin {
+			t.Errorf(`ParseWithLocale("%s") returned "%v", expected %s at %d`, test.expr, err, test.kind, test.begin)
+		}
+	}
+}
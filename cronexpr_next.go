@@ -33,35 +33,52 @@ var dowNormalizedOffsets = [][]int{
 
 /******************************************************************************/
 
+// The Gregorian calendar repeats itself every 400 years: a cron expression
+// with no restriction on years which does not fire within 400 consecutive
+// years never fires.
+const yearCycle = 400
+
 func (expr *Expression) nextYear(t time.Time) time.Time {
-	// Find index at which item in list is greater or equal to
-	// candidate year
-	i := sort.SearchInts(expr.yearList, t.Year()+1)
-	if i == len(expr.yearList) {
-		return time.Time{}
+	year := t.Year()
+	for n := 0; n < yearCycle || expr.yearList != nil; n++ {
+		var ok bool
+		if year, ok = expr.followingYear(year); !ok {
+			break
+		}
+		// Year changed, need to recalculate actual days of month
+		for _, month := range expr.monthList {
+			actualDaysOfMonthList := expr.calculateActualDaysOfMonth(year, month)
+			if len(actualDaysOfMonthList) > 0 {
+				return time.Date(
+					year,
+					time.Month(month),
+					actualDaysOfMonthList[0],
+					expr.hourList[0],
+					expr.minuteList[0],
+					expr.secondList[0],
+					0,
+					t.Location())
+			}
+		}
 	}
-	// Year changed, need to recalculate actual days of month
-	expr.actualDaysOfMonthList = expr.calculateActualDaysOfMonth(expr.yearList[i], expr.monthList[0])
-	if len(expr.actualDaysOfMonthList) == 0 {
-		return expr.nextMonth(time.Date(
-			expr.yearList[i],
-			time.Month(expr.monthList[0]),
-			1,
-			expr.hourList[0],
-			expr.minuteList[0],
-			expr.secondList[0],
-			0,
-			t.Location()))
+	return time.Time{}
+}
+
+// followingYear returns the first year of the cron expression after `year`.
+func (expr *Expression) followingYear(year int) (int, bool) {
+	if expr.yearList == nil {
+		return year + 1, true
 	}
-	return time.Date(
-		expr.yearList[i],
-		time.Month(expr.monthList[0]),
-		expr.actualDaysOfMonthList[0],
-		expr.hourList[0],
-		expr.minuteList[0],
-		expr.secondList[0],
-		0,
-		t.Location())
+	i := sort.SearchInts(expr.yearList, year+1)
+	if i == len(expr.yearList) {
+		return 0, false
+	}
+	return expr.yearList[i], true
+}
+
+// isYear returns whether `year` is a year of the cron expression.
+func (expr *Expression) isYear(year int) bool {
+	return expr.yearList == nil || containsInt(expr.yearList, year)
 }
 
 /******************************************************************************/
@@ -74,8 +91,8 @@ func (expr *Expression) nextMonth(t time.Time) time.Time {
 		return expr.nextYear(t)
 	}
 	// Month changed, need to recalculate actual days of month
-	expr.actualDaysOfMonthList = expr.calculateActualDaysOfMonth(t.Year(), expr.monthList[i])
-	if len(expr.actualDaysOfMonthList) == 0 {
+	actualDaysOfMonthList := expr.calculateActualDaysOfMonth(t.Year(), expr.monthList[i])
+	if len(actualDaysOfMonthList) == 0 {
 		return expr.nextMonth(time.Date(
 			t.Year(),
 			time.Month(expr.monthList[i]),
@@ -90,7 +107,7 @@ func (expr *Expression) nextMonth(t time.Time) time.Time {
 	return time.Date(
 		t.Year(),
 		time.Month(expr.monthList[i]),
-		expr.actualDaysOfMonthList[0],
+		actualDaysOfMonthList[0],
 		expr.hourList[0],
 		expr.minuteList[0],
 		expr.secondList[0],
@@ -101,17 +118,21 @@ func (expr *Expression) nextMonth(t time.Time) time.Time {
 /******************************************************************************/
 
 func (expr *Expression) nextDayOfMonth(t time.Time) time.Time {
+	// Actual days of month are computed on the fly rather than cached on
+	// the expression, so that an Expression can be shared by goroutines
+	actualDaysOfMonthList := expr.calculateActualDaysOfMonth(t.Year(), int(t.Month()))
+
 	// Find index at which item in list is greater or equal to
 	// candidate day of month
-	i := sort.SearchInts(expr.actualDaysOfMonthList, t.Day()+1)
-	if i == len(expr.actualDaysOfMonthList) {
+	i := sort.SearchInts(actualDaysOfMonthList, t.Day()+1)
+	if i == len(actualDaysOfMonthList) {
 		return expr.nextMonth(t)
 	}
 
 	return time.Date(
 		t.Year(),
 		t.Month(),
-		expr.actualDaysOfMonthList[i],
+		actualDaysOfMonthList[i],
 		expr.hourList[0],
 		expr.minuteList[0],
 		expr.secondList[0],
@@ -203,6 +224,10 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 		return genericDefaultList[1 : lastDayOfMonth.Day()+1]
 	}
 
+	// Quartz: "Friday the 13th", the command will be run when both fields
+	// match the current time
+	intersect := expr.daysIntersect() && expr.daysOfMonthRestricted && expr.daysOfWeekRestricted
+
 	// day-of-month != `*`
 	if expr.daysOfMonthRestricted {
 		// Last day of month
@@ -232,6 +257,10 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 
 	// day-of-week != `*`
 	if expr.daysOfWeekRestricted {
+		daysOfWeekMap := actualDaysOfMonthMap
+		if intersect {
+			daysOfWeekMap = make(map[int]bool)
+		}
 		// How far first sunday is from first day of month
 		offset := 7 - int(firstDayOfMonth.Weekday())
 		// days of week
@@ -239,12 +268,12 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 		//  target : 1 + (7 * week_of_month) + (offset + day_of_week) % 7
 		for v := range expr.daysOfWeek {
 			w := dowNormalizedOffsets[(offset+v)%7]
-			actualDaysOfMonthMap[w[0]] = true
-			actualDaysOfMonthMap[w[1]] = true
-			actualDaysOfMonthMap[w[2]] = true
-			actualDaysOfMonthMap[w[3]] = true
+			daysOfWeekMap[w[0]] = true
+			daysOfWeekMap[w[1]] = true
+			daysOfWeekMap[w[2]] = true
+			daysOfWeekMap[w[3]] = true
 			if len(w) > 4 && w[4] <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[w[4]] = true
+				daysOfWeekMap[w[4]] = true
 			}
 		}
 		// days of week of specific week in the month
@@ -253,7 +282,7 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 		for v := range expr.specificWeekDaysOfWeek {
 			v = 1 + 7*(v/7) + (offset+v)%7
 			if v <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[v] = true
+				daysOfWeekMap[v] = true
 			}
 		}
 		// Last days of week of the month
@@ -262,7 +291,14 @@ func (expr *Expression) calculateActualDaysOfMonth(year, month int) []int {
 		for v := range expr.lastWeekDaysOfWeek {
 			v = lastWeekOrigin.Day() + (offset+v)%7
 			if v <= lastDayOfMonth.Day() {
-				actualDaysOfMonthMap[v] = true
+				daysOfWeekMap[v] = true
+			}
+		}
+		if intersect {
+			for v := range actualDaysOfMonthMap {
+				if daysOfWeekMap[v] == false {
+					delete(actualDaysOfMonthMap, v)
+				}
 			}
 		}
 	}
@@ -290,3 +326,64 @@ func workdayOfMonth(targetDom, lastDom time.Time) int {
 	}
 	return dom
 }
+
+/******************************************************************************/
+
+// isActualDayOfMonth is the allocation-free counterpart of
+// calculateActualDaysOfMonth() for a single day.
+func (expr *Expression) isActualDayOfMonth(year, month, day int) bool {
+	firstDayOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	lastDayOfMonth := firstDayOfMonth.AddDate(0, 1, -1)
+	if day < 1 || day > lastDayOfMonth.Day() {
+		return false
+	}
+
+	// If both fields are not restricted, all days of the month are a hit
+	if expr.daysOfMonthRestricted == false && expr.daysOfWeekRestricted == false {
+		return true
+	}
+
+	domHit := expr.daysOfMonthRestricted && expr.isDayOfMonth(firstDayOfMonth, lastDayOfMonth, day)
+	dowHit := expr.daysOfWeekRestricted && expr.isDayOfWeek(firstDayOfMonth, lastDayOfMonth, day)
+
+	// Quartz: both fields must match, the unrestricted one always does
+	if expr.daysIntersect() {
+		return (domHit || !expr.daysOfMonthRestricted) && (dowHit || !expr.daysOfWeekRestricted)
+	}
+	return domHit || dowHit
+}
+
+// isDayOfMonth returns whether `day` matches the day-of-month field.
+func (expr *Expression) isDayOfMonth(firstDayOfMonth, lastDayOfMonth time.Time, day int) bool {
+	if expr.daysOfMonth[day] {
+		return true
+	}
+	if expr.lastDayOfMonth && day == lastDayOfMonth.Day() {
+		return true
+	}
+	if expr.lastWorkdayOfMonth && day == workdayOfMonth(lastDayOfMonth, lastDayOfMonth) {
+		return true
+	}
+	for v := range expr.workdaysOfMonth {
+		if v <= lastDayOfMonth.Day() && day == workdayOfMonth(firstDayOfMonth.AddDate(0, 0, v-1), lastDayOfMonth) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDayOfWeek returns whether `day` matches the day-of-week field.
+func (expr *Expression) isDayOfWeek(firstDayOfMonth, lastDayOfMonth time.Time, day int) bool {
+	dow := int(firstDayOfMonth.AddDate(0, 0, day-1).Weekday())
+	if expr.daysOfWeek[dow] {
+		return true
+	}
+	// Same key as the one used by dowFieldHandler() for `5#3`
+	if expr.specificWeekDaysOfWeek[7*((day-1)/7)+dow] {
+		return true
+	}
+	if expr.lastWeekDaysOfWeek[dow] && day+7 > lastDayOfMonth.Day() {
+		return true
+	}
+	return false
+}
@@ -15,104 +15,76 @@ package cronexpr
 /******************************************************************************/
 
 import (
-	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 /******************************************************************************/
 
 var (
-	genericDefaultList = []int{
-		0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
-		10, 11, 12, 13, 14, 15, 16, 17, 18, 19,
-		20, 21, 22, 23, 24, 25, 26, 27, 28, 29,
-		30, 31, 32, 33, 34, 35, 36, 37, 38, 39,
-		40, 41, 42, 43, 44, 45, 46, 47, 48, 49,
-		50, 51, 52, 53, 54, 55, 56, 57, 58, 59,
-	}
-	yearDefaultList = []int{
-		1970, 1971, 1972, 1973, 1974, 1975, 1976, 1977, 1978, 1979,
-		1980, 1981, 1982, 1983, 1984, 1985, 1986, 1987, 1988, 1989,
-		1990, 1991, 1992, 1993, 1994, 1995, 1996, 1997, 1998, 1999,
-		2000, 2001, 2002, 2003, 2004, 2005, 2006, 2007, 2008, 2009,
-		2010, 2011, 2012, 2013, 2014, 2015, 2016, 2017, 2018, 2019,
-		2020, 2021, 2022, 2023, 2024, 2025, 2026, 2027, 2028, 2029,
-		2030, 2031, 2032, 2033, 2034, 2035, 2036, 2037, 2038, 2039,
-		2040, 2041, 2042, 2043, 2044, 2045, 2046, 2047, 2048, 2049,
-		2050, 2051, 2052, 2053, 2054, 2055, 2056, 2057, 2058, 2059,
-		2060, 2061, 2062, 2063, 2064, 2065, 2066, 2067, 2068, 2069,
-		2070, 2071, 2072, 2073, 2074, 2075, 2076, 2077, 2078, 2079,
-		2080, 2081, 2082, 2083, 2084, 2085, 2086, 2087, 2088, 2089,
-		2090, 2091, 2092, 2093, 2094, 2095, 2096, 2097, 2098, 2099,
-	}
+	genericDefaultList = intRange(0, 59)
 )
 
+// intRange returns the list of integers from `min` to `max` inclusively.
+func intRange(min, max int) []int {
+	list := make([]int, 0, max-min+1)
+	for i := min; i <= max; i++ {
+		list = append(list, i)
+	}
+	return list
+}
+
 /******************************************************************************/
 
 var (
-	numberTokens = map[string]int{
-		"0": 0, "1": 1, "2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9,
-		"00": 0, "01": 1, "02": 2, "03": 3, "04": 4, "05": 5, "06": 6, "07": 7, "08": 8, "09": 9,
-		"10": 10, "11": 11, "12": 12, "13": 13, "14": 14, "15": 15, "16": 16, "17": 17, "18": 18, "19": 19,
-		"20": 20, "21": 21, "22": 22, "23": 23, "24": 24, "25": 25, "26": 26, "27": 27, "28": 28, "29": 29,
-		"30": 30, "31": 31, "32": 32, "33": 33, "34": 34, "35": 35, "36": 36, "37": 37, "38": 38, "39": 39,
-		"40": 40, "41": 41, "42": 42, "43": 43, "44": 44, "45": 45, "46": 46, "47": 47, "48": 48, "49": 49,
-		"50": 50, "51": 51, "52": 52, "53": 53, "54": 54, "55": 55, "56": 56, "57": 57, "58": 58, "59": 59,
-		"1970": 1970, "1971": 1971, "1972": 1972, "1973": 1973, "1974": 1974, "1975": 1975, "1976": 1976, "1977": 1977, "1978": 1978, "1979": 1979,
-		"1980": 1980, "1981": 1981, "1982": 1982, "1983": 1983, "1984": 1984, "1985": 1985, "1986": 1986, "1987": 1987, "1988": 1988, "1989": 1989,
-		"1990": 1990, "1991": 1991, "1992": 1992, "1993": 1993, "1994": 1994, "1995": 1995, "1996": 1996, "1997": 1997, "1998": 1998, "1999": 1999,
-		"2000": 2000, "2001": 2001, "2002": 2002, "2003": 2003, "2004": 2004, "2005": 2005, "2006": 2006, "2007": 2007, "2008": 2008, "2009": 2009,
-		"2010": 2010, "2011": 2011, "2012": 2012, "2013": 2013, "2014": 2014, "2015": 2015, "2016": 2016, "2017": 2017, "2018": 2018, "2019": 2019,
-		"2020": 2020, "2021": 2021, "2022": 2022, "2023": 2023, "2024": 2024, "2025": 2025, "2026": 2026, "2027": 2027, "2028": 2028, "2029": 2029,
-		"2030": 2030, "2031": 2031, "2032": 2032, "2033": 2033, "2034": 2034, "2035": 2035, "2036": 2036, "2037": 2037, "2038": 2038, "2039": 2039,
-		"2040": 2040, "2041": 2041, "2042": 2042, "2043": 2043, "2044": 2044, "2045": 2045, "2046": 2046, "2047": 2047, "2048": 2048, "2049": 2049,
-		"2050": 2050, "2051": 2051, "2052": 2052, "2053": 2053, "2054": 2054, "2055": 2055, "2056": 2056, "2057": 2057, "2058": 2058, "2059": 2059,
-		"2060": 2060, "2061": 2061, "2062": 2062, "2063": 2063, "2064": 2064, "2065": 2065, "2066": 2066, "2067": 2067, "2068": 2068, "2069": 2069,
-		"2070": 2070, "2071": 2071, "2072": 2072, "2073": 2073, "2074": 2074, "2075": 2075, "2076": 2076, "2077": 2077, "2078": 2078, "2079": 2079,
-		"2080": 2080, "2081": 2081, "2082": 2082, "2083": 2083, "2084": 2084, "2085": 2085, "2086": 2086, "2087": 2087, "2088": 2088, "2089": 2089,
-		"2090": 2090, "2091": 2091, "2092": 2092, "2093": 2093, "2094": 2094, "2095": 2095, "2096": 2096, "2097": 2097, "2098": 2098, "2099": 2099,
-	}
 	monthTokens = map[string]int{
-		`1`: 1, `jan`: 1, `january`: 1,
-		`2`: 2, `feb`: 2, `february`: 2,
-		`3`: 3, `mar`: 3, `march`: 3,
-		`4`: 4, `apr`: 4, `april`: 4,
-		`5`: 5, `may`: 5,
-		`6`: 6, `jun`: 6, `june`: 6,
-		`7`: 7, `jul`: 7, `july`: 7,
-		`8`: 8, `aug`: 8, `august`: 8,
-		`9`: 9, `sep`: 9, `september`: 9,
-		`10`: 10, `oct`: 10, `october`: 10,
-		`11`: 11, `nov`: 11, `november`: 11,
-		`12`: 12, `dec`: 12, `december`: 12,
+		`jan`: 1, `january`: 1,
+		`feb`: 2, `february`: 2,
+		`mar`: 3, `march`: 3,
+		`apr`: 4, `april`: 4,
+		`may`: 5,
+		`jun`: 6, `june`: 6,
+		`jul`: 7, `july`: 7,
+		`aug`: 8, `august`: 8,
+		`sep`: 9, `september`: 9,
+		`oct`: 10, `october`: 10,
+		`nov`: 11, `november`: 11,
+		`dec`: 12, `december`: 12,
 	}
 	dowTokens = map[string]int{
-		`0`: 0, `sun`: 0, `sunday`: 0,
-		`1`: 1, `mon`: 1, `monday`: 1,
-		`2`: 2, `tue`: 2, `tuesday`: 2,
-		`3`: 3, `wed`: 3, `wednesday`: 3,
-		`4`: 4, `thu`: 4, `thursday`: 4,
-		`5`: 5, `fri`: 5, `friday`: 5,
-		`6`: 6, `sat`: 6, `saturday`: 6,
-		`7`: 0,
+		`sun`: 0, `sunday`: 0,
+		`mon`: 1, `monday`: 1,
+		`tue`: 2, `tuesday`: 2,
+		`wed`: 3, `wednesday`: 3,
+		`thu`: 4, `thursday`: 4,
+		`fri`: 5, `friday`: 5,
+		`sat`: 6, `saturday`: 6,
 	}
 )
 
 /******************************************************************************/
 
+// atoi returns the value of a string of digits, or 0 if the value is not
+// representable.
 func atoi(s string) int {
-	return numberTokens[s]
+	v, _ := strconv.Atoi(s)
+	return v
 }
 
 type fieldDescriptor struct {
 	name         string
 	min, max     int
+	origin       int  // first value of `*/step`
+	hashMax      int  // last value of `H`, 0 if `H` requires a range
+	wraps        bool // whether `22-2` wraps around the end of the range
 	defaultList  []int
 	valuePattern string
 	atoi         func(string) int
+	ambiguous    func(string) bool // whether a name is ambiguous, nil if none
 }
 
 var (
@@ -120,6 +92,9 @@ var (
 		name:         "second",
 		min:          0,
 		max:          59,
+		origin:       0,
+		hashMax:      59,
+		wraps:        true,
 		defaultList:  genericDefaultList[0:60],
 		valuePattern: `0?[0-9]|[1-5][0-9]`,
 		atoi:         atoi,
@@ -128,6 +103,9 @@ var (
 		name:         "minute",
 		min:          0,
 		max:          59,
+		origin:       0,
+		hashMax:      59,
+		wraps:        true,
 		defaultList:  genericDefaultList[0:60],
 		valuePattern: `0?[0-9]|[1-5][0-9]`,
 		atoi:         atoi,
@@ -136,6 +114,9 @@ var (
 		name:         "hour",
 		min:          0,
 		max:          23,
+		origin:       0,
+		hashMax:      23,
+		wraps:        true,
 		defaultList:  genericDefaultList[0:24],
 		valuePattern: `0?[0-9]|1[0-9]|2[0-3]`,
 		atoi:         atoi,
@@ -144,6 +125,9 @@ var (
 		name:         "day-of-month",
 		min:          1,
 		max:          31,
+		origin:       1,
+		hashMax:      28,
+		wraps:        true,
 		defaultList:  genericDefaultList[1:32],
 		valuePattern: `0?[1-9]|[12][0-9]|3[01]`,
 		atoi:         atoi,
@@ -152,28 +136,46 @@ var (
 		name:         "month",
 		min:          1,
 		max:          12,
+		origin:       1,
+		hashMax:      12,
+		wraps:        true,
 		defaultList:  genericDefaultList[1:13],
 		valuePattern: `0?[1-9]|1[012]|jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec|january|february|march|april|march|april|june|july|august|september|october|november|december`,
 		atoi: func(s string) int {
-			return monthTokens[s]
+			if v, ok := monthTokens[s]; ok {
+				return v
+			}
+			return atoi(s)
 		},
 	}
 	dowDescriptor = fieldDescriptor{
 		name:         "day-of-week",
 		min:          0,
 		max:          6,
+		origin:       0,
+		hashMax:      6,
+		wraps:        true,
 		defaultList:  genericDefaultList[0:7],
 		valuePattern: `0?[0-7]|sun|mon|tue|wed|thu|fri|sat|sunday|monday|tuesday|wednesday|thursday|friday|saturday`,
 		atoi: func(s string) int {
-			return dowTokens[s]
+			if v, ok := dowTokens[s]; ok {
+				return v
+			}
+			// 7 is Sunday, like 0
+			return atoi(s) % 7
 		},
 	}
+	// A nil list of years stands for any year. For compatibility with the
+	// 1970-2099 range of old, `*/step` still starts in 1970.
 	yearDescriptor = fieldDescriptor{
 		name:         "year",
-		min:          1970,
-		max:          2099,
-		defaultList:  yearDefaultList[:],
-		valuePattern: `19[789][0-9]|20[0-9]{2}`,
+		min:          1,
+		max:          9999,
+		origin:       1970,
+		hashMax:      0,
+		wraps:        false,
+		defaultList:  nil,
+		valuePattern: `[1-9][0-9]{0,3}`,
 		atoi:         atoi,
 	}
 )
@@ -192,6 +194,7 @@ var (
 	layoutLastWorkdom         = `^lw$`
 	layoutDowOfLastWeek       = `^(%value%)l$`
 	layoutDowOfSpecificWeek   = `^(%value%)#([1-5])$`
+	layoutHash                = `^h(?:\((%value%)-(%value%)\))?(?:/(\d+))?$`
 	fieldFinder               = regexp.MustCompile(`\S+`)
 	entryFinder               = regexp.MustCompile(`[^,]+`)
 	layoutRegexp              = make(map[string]*regexp.Regexp)
@@ -200,19 +203,71 @@ var (
 
 /******************************************************************************/
 
-var cronNormalizer = strings.NewReplacer(
-	"@yearly", "0 0 0 1 1 * *",
-	"@annually", "0 0 0 1 1 * *",
-	"@monthly", "0 0 0 1 * * *",
-	"@weekly", "0 0 0 * * 0 *",
-	"@daily", "0 0 0 * * * *",
-	"@hourly", "0 0 * * * * *")
+var cronAliases = map[string]string{
+	"@yearly":   "0 0 0 1 1 * *",
+	"@annually": "0 0 0 1 1 * *",
+	"@monthly":  "0 0 0 1 * * *",
+	"@weekly":   "0 0 0 * * 0 *",
+	"@daily":    "0 0 0 * * * *",
+	"@hourly":   "0 0 * * * * *",
+}
+
+/******************************************************************************/
+
+// A cronField is one whitespace-separated field of a cron expression, along
+// with its byte offsets in the cron expression. Fields resulting from the
+// expansion of a built-in alias all point to the alias itself.
+type cronField struct {
+	s     string
+	beg   int
+	end   int
+	alias bool
+}
+
+// splitFields returns the fields of a cron expression, along with the leading
+// `CRON_TZ=` or `TZ=` field, if any.
+func splitFields(cronLine string) ([]cronField, *cronField) {
+	indices := fieldFinder.FindAllStringIndex(cronLine, -1)
+	fields := make([]cronField, 0, len(indices)+6)
+	var tz *cronField
+	for i, pair := range indices {
+		s := cronLine[pair[0]:pair[1]]
+		// Maybe a time zone is specified
+		if i == 0 && (strings.HasPrefix(s, "CRON_TZ=") || strings.HasPrefix(s, "TZ=")) {
+			tz = &cronField{s, pair[0], pair[1], false}
+			continue
+		}
+		// Maybe one of the built-in aliases is being used
+		if expansion, ok := cronAliases[s]; ok && len(fields) == 0 {
+			for _, alias := range fieldFinder.FindAllString(expansion, -1) {
+				fields = append(fields, cronField{alias, pair[0], pair[1], true})
+			}
+			continue
+		}
+		fields = append(fields, cronField{s, pair[0], pair[1], false})
+	}
+	return fields, tz
+}
+
+func parseLocation(tz *cronField, cronLine string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tz.s[strings.Index(tz.s, "=")+1:])
+	if err != nil || tz.s[len(tz.s)-1] == '=' {
+		return nil, &ParseError{
+			Kind:      ErrorTimeZone,
+			Input:     cronLine,
+			Begin:     tz.beg,
+			End:       tz.end,
+			Directive: tz.s,
+		}
+	}
+	return loc, nil
+}
 
 /******************************************************************************/
 
 func (expr *Expression) secondFieldHandler(s string) error {
 	var err error
-	expr.secondList, err = genericFieldHandler(s, secondDescriptor)
+	expr.secondList, expr.directives[secondIndex], err = genericFieldHandler(s, secondDescriptor, expr.seed, expr.strict)
 	return err
 }
 
@@ -220,7 +275,7 @@ func (expr *Expression) secondFieldHandler(s string) error {
 
 func (expr *Expression) minuteFieldHandler(s string) error {
 	var err error
-	expr.minuteList, err = genericFieldHandler(s, minuteDescriptor)
+	expr.minuteList, expr.directives[minuteIndex], err = genericFieldHandler(s, minuteDescriptor, expr.seed, expr.strict)
 	return err
 }
 
@@ -228,7 +283,7 @@ func (expr *Expression) minuteFieldHandler(s string) error {
 
 func (expr *Expression) hourFieldHandler(s string) error {
 	var err error
-	expr.hourList, err = genericFieldHandler(s, hourDescriptor)
+	expr.hourList, expr.directives[hourIndex], err = genericFieldHandler(s, hourDescriptor, expr.seed, expr.strict)
 	return err
 }
 
@@ -236,16 +291,30 @@ func (expr *Expression) hourFieldHandler(s string) error {
 
 func (expr *Expression) monthFieldHandler(s string) error {
 	var err error
-	expr.monthList, err = genericFieldHandler(s, monthDescriptor)
+	expr.monthList, expr.directives[monthIndex], err = genericFieldHandler(s, expr.namesDescriptor(monthDescriptor), expr.seed, expr.strict)
 	return err
 }
 
 /******************************************************************************/
 
+// shortYearMax bounds the years of fewer than four digits, which must be part
+// of an explicit range, see ErrorShortYear.
+const shortYearMax = 1000
+
 func (expr *Expression) yearFieldHandler(s string) error {
 	var err error
-	expr.yearList, err = genericFieldHandler(s, yearDescriptor)
-	return err
+	expr.yearList, expr.directives[yearIndex], err = genericFieldHandler(s, yearDescriptor, expr.seed, expr.strict)
+	if err != nil {
+		return err
+	}
+	// `1` or `1/5` is rather a misplaced value of another field than a year
+	// of the first century
+	for _, directive := range expr.directives[yearIndex] {
+		if (directive.kind == one || directive.kind == span) && directive.first < shortYearMax && !strings.Contains(s[directive.sbeg:directive.send], "-") {
+			return newDirectiveError(ErrorShortYear, yearDescriptor, s, directive)
+		}
+	}
+	return nil
 }
 
 /******************************************************************************/
@@ -255,6 +324,23 @@ const (
 	one  = 1
 	span = 2
 	all  = 3
+	// Kinds resolved by domFieldHandler() and dowFieldHandler()
+	lastDom           = 4 // `L`
+	lastWorkdom       = 5 // `LW`
+	workdom           = 6 // `15W`, `first` is the day of month
+	dowOfLastWeek     = 7 // `5L`, `first` is the day of week
+	dowOfSpecificWeek = 8 // `5#3`, `first` is the day of week, `last` the week
+)
+
+// Indices of the fields of a cron expression in Expression.directives
+const (
+	secondIndex = iota
+	minuteIndex
+	hourIndex
+	domIndex
+	monthIndex
+	dowIndex
+	yearIndex
 )
 
 type cronDirective struct {
@@ -266,25 +352,32 @@ type cronDirective struct {
 	send  int
 }
 
-func genericFieldHandler(s string, desc fieldDescriptor) ([]int, error) {
-	directives, err := genericFieldParse(s, desc)
+// genericFieldHandler returns the sorted list of values of field `s`, along
+// with its directives.
+func genericFieldHandler(s string, desc fieldDescriptor, seed *string, strict bool) ([]int, []*cronDirective, error) {
+	directives, err := genericFieldParse(s, desc, seed, strict)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if strict {
+		if err = checkOverlaps(desc, s, directives); err != nil {
+			return nil, nil, err
+		}
 	}
 	values := make(map[int]bool)
 	for _, directive := range directives {
 		switch directive.kind {
 		case none:
-			return nil, fmt.Errorf("syntax error in %s field: '%s'", desc.name, s[directive.sbeg:directive.send])
+			return nil, nil, newSyntaxError(desc, s, directive)
 		case one:
 			populateOne(values, directive.first)
 		case span:
-			populateMany(values, directive.first, directive.last, directive.step)
+			populateMany(values, desc, directive.first, directive.last, directive.step)
 		case all:
-			return desc.defaultList, nil
+			return desc.defaultList, directives, nil
 		}
 	}
-	return toList(values), nil
+	return toList(values), directives, nil
 }
 
 func (expr *Expression) dowFieldHandler(s string) error {
@@ -293,10 +386,15 @@ func (expr *Expression) dowFieldHandler(s string) error {
 	expr.lastWeekDaysOfWeek = make(map[int]bool)
 	expr.specificWeekDaysOfWeek = make(map[int]bool)
 
-	directives, err := genericFieldParse(s, dowDescriptor)
+	desc := expr.namesDescriptor(dowDescriptor)
+	if expr.dialect == DialectQuartz {
+		desc = quartzWeekdays(desc)
+	}
+	directives, err := genericFieldParse(s, desc, expr.seed, expr.strict)
 	if err != nil {
 		return err
 	}
+	expr.directives[dowIndex] = directives
 
 	for _, directive := range directives {
 		switch directive.kind {
@@ -304,27 +402,35 @@ func (expr *Expression) dowFieldHandler(s string) error {
 			sdirective := s[directive.sbeg:directive.send]
 			snormal := strings.ToLower(sdirective)
 			// `5L`
-			pairs := makeLayoutRegexp(layoutDowOfLastWeek, dowDescriptor.valuePattern).FindStringSubmatchIndex(snormal)
+			pairs := makeLayoutRegexp(layoutDowOfLastWeek, desc.valuePattern).FindStringSubmatchIndex(snormal)
 			if len(pairs) > 0 {
-				populateOne(expr.lastWeekDaysOfWeek, dowDescriptor.atoi(snormal[pairs[2]:pairs[3]]))
+				directive.kind = dowOfLastWeek
+				directive.first = desc.atoi(snormal[pairs[2]:pairs[3]])
+				populateOne(expr.lastWeekDaysOfWeek, directive.first)
 			} else {
 				// `5#3`
-				pairs := makeLayoutRegexp(layoutDowOfSpecificWeek, dowDescriptor.valuePattern).FindStringSubmatchIndex(snormal)
+				pairs := makeLayoutRegexp(layoutDowOfSpecificWeek, desc.valuePattern).FindStringSubmatchIndex(snormal)
 				if len(pairs) > 0 {
-					populateOne(expr.specificWeekDaysOfWeek, (dowDescriptor.atoi(snormal[pairs[4]:pairs[5]])-1)*7+(dowDescriptor.atoi(snormal[pairs[2]:pairs[3]])%7))
+					directive.kind = dowOfSpecificWeek
+					directive.first = desc.atoi(snormal[pairs[2]:pairs[3]]) % 7
+					directive.last = atoi(snormal[pairs[4]:pairs[5]])
+					populateOne(expr.specificWeekDaysOfWeek, (directive.last-1)*7+directive.first)
 				} else {
-					return fmt.Errorf("syntax error in day-of-week field: '%s'", sdirective)
+					return newSyntaxError(desc, s, directive)
 				}
 			}
 		case one:
 			populateOne(expr.daysOfWeek, directive.first)
 		case span:
-			populateMany(expr.daysOfWeek, directive.first, directive.last, directive.step)
+			populateMany(expr.daysOfWeek, desc, directive.first, directive.last, directive.step)
 		case all:
-			populateMany(expr.daysOfWeek, directive.first, directive.last, directive.step)
+			populateMany(expr.daysOfWeek, desc, directive.first, directive.last, directive.step)
 			expr.daysOfWeekRestricted = false
 		}
 	}
+	if expr.strict {
+		return checkOverlaps(desc, s, directives)
+	}
 	return nil
 }
 
@@ -335,10 +441,11 @@ func (expr *Expression) domFieldHandler(s string) error {
 	expr.daysOfMonth = make(map[int]bool)     // days of month map
 	expr.workdaysOfMonth = make(map[int]bool) // work days of month map
 
-	directives, err := genericFieldParse(s, domDescriptor)
+	directives, err := genericFieldParse(s, domDescriptor, expr.seed, expr.strict)
 	if err != nil {
 		return err
 	}
+	expr.directives[domIndex] = directives
 
 	for _, directive := range directives {
 		switch directive.kind {
@@ -347,30 +454,37 @@ func (expr *Expression) domFieldHandler(s string) error {
 			snormal := strings.ToLower(sdirective)
 			// `L`
 			if makeLayoutRegexp(layoutLastDom, domDescriptor.valuePattern).MatchString(snormal) {
+				directive.kind = lastDom
 				expr.lastDayOfMonth = true
 			} else {
 				// `LW`
 				if makeLayoutRegexp(layoutLastWorkdom, domDescriptor.valuePattern).MatchString(snormal) {
+					directive.kind = lastWorkdom
 					expr.lastWorkdayOfMonth = true
 				} else {
 					// `15W`
 					pairs := makeLayoutRegexp(layoutWorkdom, domDescriptor.valuePattern).FindStringSubmatchIndex(snormal)
 					if len(pairs) > 0 {
-						populateOne(expr.workdaysOfMonth, domDescriptor.atoi(snormal[pairs[2]:pairs[3]]))
+						directive.kind = workdom
+						directive.first = domDescriptor.atoi(snormal[pairs[2]:pairs[3]])
+						populateOne(expr.workdaysOfMonth, directive.first)
 					} else {
-						return fmt.Errorf("syntax error in day-of-month field: '%s'", sdirective)
+						return newDirectiveError(ErrorSyntax, domDescriptor, s, directive)
 					}
 				}
 			}
 		case one:
 			populateOne(expr.daysOfMonth, directive.first)
 		case span:
-			populateMany(expr.daysOfMonth, directive.first, directive.last, directive.step)
+			populateMany(expr.daysOfMonth, domDescriptor, directive.first, directive.last, directive.step)
 		case all:
-			populateMany(expr.daysOfMonth, directive.first, directive.last, directive.step)
+			populateMany(expr.daysOfMonth, domDescriptor, directive.first, directive.last, directive.step)
 			expr.daysOfMonthRestricted = false
 		}
 	}
+	if expr.strict {
+		return checkOverlaps(domDescriptor, s, directives)
+	}
 	return nil
 }
 
@@ -380,10 +494,33 @@ func populateOne(values map[int]bool, v int) {
 	values[v] = true
 }
 
-func populateMany(values map[int]bool, min, max, step int) {
-	for i := min; i <= max; i += step {
-		values[i] = true
+// populateMany populates the values from `first` to `last` by `step`. If
+// `last` is less than `first`, values wrap around the end of the range of the
+// field, e.g. `22-2/2` in the hour field yields 22, 0 and 2.
+func populateMany(values map[int]bool, desc fieldDescriptor, first, last, step int) {
+	size := desc.max - desc.min + 1
+	for i := first; i <= desc.unwrap(first, last); i += step {
+		values[desc.min+(i-desc.min)%size] = true
+	}
+}
+
+// unwrap returns `last` past the end of the range of the field if the range
+// from `first` to `last` wraps around.
+func (desc fieldDescriptor) unwrap(first, last int) int {
+	if desc.wraps && last < first {
+		return last + desc.max - desc.min + 1
+	}
+	return last
+}
+
+// rangeBound returns the value of `s` as the first or last value of a range.
+// 7 is Sunday, like 0, but `5-7` ends the week rather than wraps around,
+// unless 7 is Saturday, as with the Quartz dialect.
+func (desc fieldDescriptor) rangeBound(s string) int {
+	if desc.name == dowDescriptor.name && atoi(s) == 7 && desc.atoi(s) == 0 {
+		return 7
 	}
+	return desc.atoi(s)
 }
 
 func toList(set map[int]bool) []int {
@@ -399,11 +536,20 @@ func toList(set map[int]bool) []int {
 
 /******************************************************************************/
 
-func genericFieldParse(s string, desc fieldDescriptor) ([]*cronDirective, error) {
+// genericFieldParse parses the directives of field `s`. `H` directives are
+// resolved from `seed`, they are an error if `seed` is nil. See WithStrict
+// for `strict`.
+func genericFieldParse(s string, desc fieldDescriptor, seed *string, strict bool) ([]*cronDirective, error) {
 	// At least one entry must be present
 	indices := entryFinder.FindAllStringIndex(s, -1)
 	if len(indices) == 0 {
-		return nil, fmt.Errorf("%s field: missing directive", desc.name)
+		return nil, &ParseError{
+			Kind:  ErrorMissingDirective,
+			Input: s,
+			Field: desc.name,
+			Begin: 0,
+			End:   len(s),
+		}
 	}
 
 	directives := make([]*cronDirective, 0, len(indices))
@@ -431,13 +577,16 @@ func genericFieldParse(s string, desc fieldDescriptor) ([]*cronDirective, error)
 			directives = append(directives, &directive)
 			continue
 		}
-		// `5-20`
+		// `5-20`, `22-2`
 		pairs := makeLayoutRegexp(layoutRange, desc.valuePattern).FindStringSubmatchIndex(snormal)
 		if len(pairs) > 0 {
 			directive.kind = span
-			directive.first = desc.atoi(snormal[pairs[2]:pairs[3]])
-			directive.last = desc.atoi(snormal[pairs[4]:pairs[5]])
+			directive.first = desc.rangeBound(snormal[pairs[2]:pairs[3]])
+			directive.last = desc.rangeBound(snormal[pairs[4]:pairs[5]])
 			directive.step = 1
+			if strict && desc.unwrap(directive.first, directive.last) < directive.first {
+				return nil, newDirectiveError(ErrorEmptyRange, desc, s, &directive)
+			}
 			directives = append(directives, &directive)
 			continue
 		}
@@ -445,11 +594,16 @@ func genericFieldParse(s string, desc fieldDescriptor) ([]*cronDirective, error)
 		pairs = makeLayoutRegexp(layoutWildcardAndInterval, desc.valuePattern).FindStringSubmatchIndex(snormal)
 		if len(pairs) > 0 {
 			directive.kind = span
-			directive.first = desc.min
+			directive.first = desc.origin
 			directive.last = desc.max
 			directive.step = atoi(snormal[pairs[2]:pairs[3]])
 			if directive.step < 1 || directive.step > desc.max {
-				return nil, fmt.Errorf("invalid interval %s", snormal)
+				return nil, newDirectiveError(ErrorInterval, desc, s, &directive)
+			}
+			if strict {
+				if err := checkStep(desc, s, &directive); err != nil {
+					return nil, err
+				}
 			}
 			directives = append(directives, &directive)
 			continue
@@ -462,20 +616,41 @@ func genericFieldParse(s string, desc fieldDescriptor) ([]*cronDirective, error)
 			directive.last = desc.max
 			directive.step = atoi(snormal[pairs[4]:pairs[5]])
 			if directive.step < 1 || directive.step > desc.max {
-				return nil, fmt.Errorf("invalid interval %s", snormal)
+				return nil, newDirectiveError(ErrorInterval, desc, s, &directive)
+			}
+			if strict {
+				if err := checkStep(desc, s, &directive); err != nil {
+					return nil, err
+				}
 			}
 			directives = append(directives, &directive)
 			continue
 		}
-		// `5-20/2`
+		// `5-20/2`, `22-4/2`
 		pairs = makeLayoutRegexp(layoutRangeAndInterval, desc.valuePattern).FindStringSubmatchIndex(snormal)
 		if len(pairs) > 0 {
 			directive.kind = span
-			directive.first = desc.atoi(snormal[pairs[2]:pairs[3]])
-			directive.last = desc.atoi(snormal[pairs[4]:pairs[5]])
+			directive.first = desc.rangeBound(snormal[pairs[2]:pairs[3]])
+			directive.last = desc.rangeBound(snormal[pairs[4]:pairs[5]])
 			directive.step = atoi(snormal[pairs[6]:pairs[7]])
 			if directive.step < 1 || directive.step > desc.max {
-				return nil, fmt.Errorf("invalid interval %s", snormal)
+				return nil, newDirectiveError(ErrorInterval, desc, s, &directive)
+			}
+			if strict && desc.unwrap(directive.first, directive.last) < directive.first {
+				return nil, newDirectiveError(ErrorEmptyRange, desc, s, &directive)
+			}
+			if strict {
+				if err := checkStep(desc, s, &directive); err != nil {
+					return nil, err
+				}
+			}
+			directives = append(directives, &directive)
+			continue
+		}
+		// `H`, `H(0-29)`, `H/15`, `H(0-29)/10`
+		if ok, err := parseHashDirective(s, snormal, desc, seed, &directive); ok {
+			if err != nil {
+				return nil, err
 			}
 			directives = append(directives, &directive)
 			continue
@@ -0,0 +1,184 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_prev.go
+ * Version: 1.0
+ * License: pick the one which suits you :
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"sort"
+	"time"
+)
+
+/******************************************************************************/
+
+func (expr *Expression) prevYear(t time.Time) time.Time {
+	year := t.Year()
+	for n := 0; n < yearCycle || expr.yearList != nil; n++ {
+		var ok bool
+		if year, ok = expr.precedingYear(year); !ok {
+			break
+		}
+		// Year changed, need to recalculate actual days of month
+		for i := len(expr.monthList) - 1; i >= 0; i-- {
+			month := expr.monthList[i]
+			actualDaysOfMonthList := expr.calculateActualDaysOfMonth(year, month)
+			if len(actualDaysOfMonthList) > 0 {
+				return time.Date(
+					year,
+					time.Month(month),
+					actualDaysOfMonthList[len(actualDaysOfMonthList)-1],
+					expr.hourList[len(expr.hourList)-1],
+					expr.minuteList[len(expr.minuteList)-1],
+					expr.secondList[len(expr.secondList)-1],
+					0,
+					t.Location())
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// precedingYear returns the last year of the cron expression before `year`.
+func (expr *Expression) precedingYear(year int) (int, bool) {
+	if expr.yearList == nil {
+		return year - 1, true
+	}
+	i := sort.SearchInts(expr.yearList, year)
+	if i == 0 {
+		return 0, false
+	}
+	return expr.yearList[i-1], true
+}
+
+/******************************************************************************/
+
+func (expr *Expression) prevMonth(t time.Time) time.Time {
+	// Find index at which item in list is greater or equal to
+	// candidate month, the one before is the closest earlier month
+	i := sort.SearchInts(expr.monthList, int(t.Month()))
+	if i == 0 {
+		return expr.prevYear(t)
+	}
+	// Month changed, need to recalculate actual days of month
+	actualDaysOfMonthList := expr.calculateActualDaysOfMonth(t.Year(), expr.monthList[i-1])
+	if len(actualDaysOfMonthList) == 0 {
+		return expr.prevMonth(time.Date(
+			t.Year(),
+			time.Month(expr.monthList[i-1]),
+			1,
+			expr.hourList[len(expr.hourList)-1],
+			expr.minuteList[len(expr.minuteList)-1],
+			expr.secondList[len(expr.secondList)-1],
+			0,
+			t.Location()))
+	}
+
+	return time.Date(
+		t.Year(),
+		time.Month(expr.monthList[i-1]),
+		actualDaysOfMonthList[len(actualDaysOfMonthList)-1],
+		expr.hourList[len(expr.hourList)-1],
+		expr.minuteList[len(expr.minuteList)-1],
+		expr.secondList[len(expr.secondList)-1],
+		0,
+		t.Location())
+}
+
+/******************************************************************************/
+
+func (expr *Expression) prevDayOfMonth(t time.Time) time.Time {
+	actualDaysOfMonthList := expr.calculateActualDaysOfMonth(t.Year(), int(t.Month()))
+
+	// Find index at which item in list is greater or equal to
+	// candidate day of month, the one before is the closest earlier day
+	i := sort.SearchInts(actualDaysOfMonthList, t.Day())
+	if i == 0 {
+		return expr.prevMonth(t)
+	}
+
+	return time.Date(
+		t.Year(),
+		t.Month(),
+		actualDaysOfMonthList[i-1],
+		expr.hourList[len(expr.hourList)-1],
+		expr.minuteList[len(expr.minuteList)-1],
+		expr.secondList[len(expr.secondList)-1],
+		0,
+		t.Location())
+}
+
+/******************************************************************************/
+
+func (expr *Expression) prevHour(t time.Time) time.Time {
+	// Find index at which item in list is greater or equal to
+	// candidate hour, the one before is the closest earlier hour
+	i := sort.SearchInts(expr.hourList, t.Hour())
+	if i == 0 {
+		return expr.prevDayOfMonth(t)
+	}
+
+	return time.Date(
+		t.Year(),
+		t.Month(),
+		t.Day(),
+		expr.hourList[i-1],
+		expr.minuteList[len(expr.minuteList)-1],
+		expr.secondList[len(expr.secondList)-1],
+		0,
+		t.Location())
+}
+
+/******************************************************************************/
+
+func (expr *Expression) prevMinute(t time.Time) time.Time {
+	// Find index at which item in list is greater or equal to
+	// candidate minute, the one before is the closest earlier minute
+	i := sort.SearchInts(expr.minuteList, t.Minute())
+	if i == 0 {
+		return expr.prevHour(t)
+	}
+
+	return time.Date(
+		t.Year(),
+		t.Month(),
+		t.Day(),
+		t.Hour(),
+		expr.minuteList[i-1],
+		expr.secondList[len(expr.secondList)-1],
+		0,
+		t.Location())
+}
+
+/******************************************************************************/
+
+func (expr *Expression) prevSecond(t time.Time) time.Time {
+	// prevSecond() assumes all other fields are exactly matched
+	// to the cron expression
+
+	// Find index at which item in list is greater or equal to
+	// candidate second, the one before is the closest earlier second
+	i := sort.SearchInts(expr.secondList, t.Second())
+	if i == 0 {
+		return expr.prevMinute(t)
+	}
+
+	return time.Date(
+		t.Year(),
+		t.Month(),
+		t.Day(),
+		t.Hour(),
+		t.Minute(),
+		expr.secondList[i-1],
+		0,
+		t.Location())
+}
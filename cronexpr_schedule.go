@@ -0,0 +1,149 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_schedule.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"sort"
+	"time"
+)
+
+/******************************************************************************/
+
+// A Schedule is anything which tells when something must happen, e.g. an
+// Expression or a list of time instants. Code written against Schedule works
+// with all of them.
+type Schedule interface {
+	// Next returns the closest time instant immediately following
+	// `fromTime`, or the zero value of time.Time if there is none.
+	Next(fromTime time.Time) time.Time
+	// Prev returns the closest time instant immediately preceding
+	// `fromTime`, or the zero value of time.Time if there is none.
+	Prev(fromTime time.Time) time.Time
+}
+
+var _ Schedule = (*Expression)(nil)
+
+/******************************************************************************/
+
+// timeList is a Schedule firing at a fixed set of time instants, sorted in
+// chronological ascending order, without duplicates.
+type timeList []time.Time
+
+// At returns a Schedule which fires once, at time instant `t`.
+func At(t time.Time) Schedule {
+	return Times(t)
+}
+
+// Times returns a Schedule which fires at each of the time instants `times`,
+// in whatever order they are supplied. Zero time values are ignored.
+//
+// Like with an Expression, the `time.Location` of the time instants returned
+// by the Schedule is that of the time instant passed as argument.
+func Times(times ...time.Time) Schedule {
+	list := make(timeList, 0, len(times))
+	for _, t := range times {
+		if t.IsZero() == false {
+			list = append(list, t)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Before(list[j])
+	})
+	// Remove duplicates
+	n := 0
+	for i := range list {
+		if n == 0 || list[i].Equal(list[n-1]) == false {
+			list[n] = list[i]
+			n += 1
+		}
+	}
+	return list[:n]
+}
+
+func (list timeList) Next(fromTime time.Time) time.Time {
+	if fromTime.IsZero() {
+		return fromTime
+	}
+	i := sort.Search(len(list), func(i int) bool {
+		return list[i].After(fromTime)
+	})
+	if i == len(list) {
+		return time.Time{}
+	}
+	return list[i].In(fromTime.Location())
+}
+
+func (list timeList) Prev(fromTime time.Time) time.Time {
+	if fromTime.IsZero() {
+		return fromTime
+	}
+	i := sort.Search(len(list), func(i int) bool {
+		return !list[i].Before(fromTime)
+	})
+	if i == 0 {
+		return time.Time{}
+	}
+	return list[i-1].In(fromTime.Location())
+}
+
+/******************************************************************************/
+
+// NextN returns a slice of up to `n` closest time instants following
+// `fromTime` as per Schedule `s`, in chronological ascending order.
+func NextN(s Schedule, fromTime time.Time, n uint) []time.Time {
+	if expr, ok := s.(*Expression); ok {
+		return expr.NextN(fromTime, n)
+	}
+	nextTimes := make([]time.Time, 0, n)
+	for t := fromTime; uint(len(nextTimes)) < n; {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		nextTimes = append(nextTimes, t)
+	}
+	return nextTimes
+}
+
+// PrevN returns a slice of up to `n` closest time instants preceding
+// `fromTime` as per Schedule `s`, in chronological descending order.
+func PrevN(s Schedule, fromTime time.Time, n uint) []time.Time {
+	if expr, ok := s.(*Expression); ok {
+		return expr.PrevN(fromTime, n)
+	}
+	prevTimes := make([]time.Time, 0, n)
+	for t := fromTime; uint(len(prevTimes)) < n; {
+		if t = s.Prev(t); t.IsZero() {
+			break
+		}
+		prevTimes = append(prevTimes, t)
+	}
+	return prevTimes
+}
+
+// Between returns all the time instants of Schedule `s` from `fromTime`
+// inclusively to `toTime` exclusively, in chronological ascending order.
+func Between(s Schedule, fromTime, toTime time.Time) []time.Time {
+	if expr, ok := s.(*Expression); ok {
+		return expr.Between(fromTime, toTime)
+	}
+	var times []time.Time
+	if fromTime.IsZero() {
+		return times
+	}
+	for t := s.Next(fromTime.Add(-time.Nanosecond)); t.IsZero() == false && t.Before(toTime); t = s.Next(t) {
+		times = append(times, t)
+	}
+	return times
+}
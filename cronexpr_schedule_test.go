@@ -0,0 +1,131 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_schedule_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+func mustParseTimes(t *testing.T, values ...string) []time.Time {
+	times := make([]time.Time, len(values))
+	for i, value := range values {
+		var err error
+		if times[i], err = time.Parse(time.RFC3339, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return times
+}
+
+func formatTimes(times []time.Time) []string {
+	values := make([]string, len(times))
+	for i, t := range times {
+		values[i] = t.Format(time.RFC3339)
+	}
+	return values
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/******************************************************************************/
+
+func TestTimes(t *testing.T) {
+	times := mustParseTimes(t,
+		"2024-03-01T12:00:00Z",
+		"2024-01-01T00:00:00Z",
+		"2024-01-01T01:00:00+01:00", // same instant as above
+		"2024-02-01T00:00:00Z",
+	)
+	s := Times(append(times, time.Time{})...)
+	from, _ := time.Parse(time.RFC3339, "2023-12-31T00:00:00Z")
+
+	next := formatTimes(NextN(s, from, 5))
+	expected := []string{"2024-01-01T00:00:00Z", "2024-02-01T00:00:00Z", "2024-03-01T12:00:00Z"}
+	if !equalStrings(next, expected) {
+		t.Errorf(`NextN(Times()) = %v, expected %v`, next, expected)
+	}
+	to, _ := time.Parse(time.RFC3339, "2024-12-31T00:00:00Z")
+	prev := formatTimes(PrevN(s, to, 2))
+	expected = []string{"2024-03-01T12:00:00Z", "2024-02-01T00:00:00Z"}
+	if !equalStrings(prev, expected) {
+		t.Errorf(`PrevN(Times()) = %v, expected %v`, prev, expected)
+	}
+	between := formatTimes(Between(s, times[1], times[0]))
+	expected = []string{"2024-01-01T00:00:00Z", "2024-02-01T00:00:00Z"}
+	if !equalStrings(between, expected) {
+		t.Errorf(`Between(Times()) = %v, expected %v`, between, expected)
+	}
+
+	// Strictly after, strictly before
+	if next := s.Next(times[1]); !next.Equal(times[3]) {
+		t.Errorf(`Times().Next("%s") = "%s", expected "%s"`, times[1], next, times[3])
+	}
+	if prev := s.Prev(times[3]); !prev.Equal(times[1]) {
+		t.Errorf(`Times().Prev("%s") = "%s", expected "%s"`, times[3], prev, times[1])
+	}
+	if next := s.Next(times[0]); !next.IsZero() {
+		t.Errorf(`Times().Next("%s") = "%s", expected zero time`, times[0], next)
+	}
+	if next := s.Next(time.Time{}); !next.IsZero() {
+		t.Errorf(`Times().Next(time.Time{}) = "%s", expected zero time`, next)
+	}
+
+	// Location of the argument
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	if next := s.Next(from.In(loc)); next.Location() != loc {
+		t.Errorf(`Times().Next() returned a time in %s, expected %s`, next.Location(), loc)
+	}
+}
+
+func TestAt(t *testing.T) {
+	at, _ := time.Parse(time.RFC3339, "2024-06-01T08:00:00Z")
+	s := At(at)
+	if next := NextN(s, at.Add(-time.Hour), 3); len(next) != 1 || !next[0].Equal(at) {
+		t.Errorf(`NextN(At()) = %v, expected [%s]`, next, at)
+	}
+	if prev := s.Prev(at.Add(time.Nanosecond)); !prev.Equal(at) {
+		t.Errorf(`At().Prev() = "%s", expected "%s"`, prev, at)
+	}
+}
+
+// Helpers written once against Schedule work with an Expression as well.
+func TestScheduleExpression(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	for _, s := range []Schedule{MustParse("0 0 * * MON"), MustParse("@every 168h from 2024-01-01T00:00:00Z")} {
+		next := formatTimes(NextN(s, from, 2))
+		expected := []string{"2024-01-08T00:00:00Z", "2024-01-15T00:00:00Z"}
+		if !equalStrings(next, expected) {
+			t.Errorf(`NextN(%v) = %v, expected %v`, s, next, expected)
+		}
+		prev := formatTimes(PrevN(s, from.AddDate(0, 0, 8), 2))
+		expected = []string{"2024-01-08T00:00:00Z", "2024-01-01T00:00:00Z"}
+		if !equalStrings(prev, expected) {
+			t.Errorf(`PrevN(%v) = %v, expected %v`, s, prev, expected)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_sql.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+/******************************************************************************/
+
+// Scan implements the sql.Scanner interface. The column must hold a cron
+// expression as a string or a byte slice, which is parsed with Parse: the
+// error returned by Parse, if any, is returned as is. Any other value,
+// including NULL, yields a *ParseError of kind ErrorScan, whose Directive is
+// the Go type of the value, or "NULL". Use NullExpression for nullable
+// columns.
+func (expr *Expression) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return expr.UnmarshalText([]byte(src))
+	case []byte:
+		return expr.UnmarshalText(src)
+	case nil:
+		return &ParseError{Kind: ErrorScan, Directive: "NULL"}
+	}
+	return &ParseError{Kind: ErrorScan, Directive: fmt.Sprintf("%T", src)}
+}
+
+// Value implements the driver.Valuer interface. The canonical form of the
+// cron expression is stored, see MarshalText: ErrNotEncodable is returned
+// if the canonical form would be scanned back into a different schedule.
+func (expr Expression) Value() (driver.Value, error) {
+	text, err := expr.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+/******************************************************************************/
+
+// NullExpression represents an Expression that may be NULL. NullExpression
+// implements the sql.Scanner and driver.Valuer interfaces so it can be used
+// as a scan destination and as a query argument, similar to sql.NullString.
+type NullExpression struct {
+	Expression Expression
+	Valid      bool // Valid is true if Expression is not NULL
+}
+
+// Scan implements the sql.Scanner interface.
+func (nexpr *NullExpression) Scan(src interface{}) error {
+	if src == nil {
+		nexpr.Expression, nexpr.Valid = Expression{}, false
+		return nil
+	}
+	if err := nexpr.Expression.Scan(src); err != nil {
+		nexpr.Valid = false
+		return err
+	}
+	nexpr.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (nexpr NullExpression) Value() (driver.Value, error) {
+	if !nexpr.Valid {
+		return nil, nil
+	}
+	return nexpr.Expression.Value()
+}
@@ -0,0 +1,210 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_sql_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/******************************************************************************/
+
+// A tiny in-memory driver: each DSN is a single-column table, `INSERT`
+// appends a row, anything else returns all rows.
+
+type fakeDriver struct{}
+type fakeConn struct{ dsn string }
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+type fakeRows struct {
+	rows []driver.Value
+	i    int
+}
+
+var (
+	fakeTables     = make(map[string][]driver.Value)
+	fakeTablesLock sync.Mutex
+)
+
+func init() {
+	sql.Register("cronexpr-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	return &fakeConn{dsn}, nil
+}
+
+func (conn *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn, query}, nil
+}
+
+func (conn *fakeConn) Close() error {
+	return nil
+}
+
+func (conn *fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+func (stmt *fakeStmt) Close() error {
+	return nil
+}
+
+func (stmt *fakeStmt) NumInput() int {
+	return strings.Count(stmt.query, "?")
+}
+
+func (stmt *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeTablesLock.Lock()
+	defer fakeTablesLock.Unlock()
+	fakeTables[stmt.conn.dsn] = append(fakeTables[stmt.conn.dsn], args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (stmt *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	fakeTablesLock.Lock()
+	defer fakeTablesLock.Unlock()
+	return &fakeRows{rows: append([]driver.Value(nil), fakeTables[stmt.conn.dsn]...)}, nil
+}
+
+func (rows *fakeRows) Columns() []string {
+	return []string{"schedule"}
+}
+
+func (rows *fakeRows) Close() error {
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.i == len(rows.rows) {
+		return io.EOF
+	}
+	dest[0] = rows.rows[rows.i]
+	rows.i += 1
+	return nil
+}
+
+/******************************************************************************/
+
+func openFakeTable(t *testing.T, values ...interface{}) *sql.DB {
+	db, err := sql.Open("cronexpr-fake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range values {
+		if _, err = db.Exec("INSERT ?", v); err != nil {
+			t.Fatalf(`db.Exec("INSERT ?", %v) returned "%s"`, v, err)
+		}
+	}
+	return db
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	db := openFakeTable(t, *MustParse("@daily"), MustParse("*/5 * * * *"), "0 0 * * MON", []byte("0 0 L * *"))
+	defer db.Close()
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	expected := []string{"0 0 0 * * * *", "0 */5 * * * * *", "0 0 0 * * 1 *", "0 0 0 L * * *"}
+	i := 0
+	for rows.Next() {
+		var expr Expression
+		if err = rows.Scan(&expr); err != nil {
+			t.Errorf(`rows.Scan() returned "%s"`, err)
+		} else if expr.String() != expected[i] {
+			t.Errorf(`rows.Scan() returned "%s", expected "%s"`, expr.String(), expected[i])
+		}
+		i += 1
+	}
+	if i != len(expected) {
+		t.Errorf(`rows.Scan() returned %d rows, expected %d`, i, len(expected))
+	}
+}
+
+func TestSQLNotEncodable(t *testing.T) {
+	db := openFakeTable(t)
+	defer db.Close()
+
+	expr, _ := ParseWithOptions("0 0 13 * FRI", WithDialect(DialectQuartz))
+	if _, err := db.Exec("INSERT ?", expr); err == nil {
+		t.Errorf(`db.Exec("INSERT ?") of a Quartz expression returned no error`)
+	}
+	if _, err := expr.Value(); err != ErrNotEncodable {
+		t.Errorf(`Value() returned "%v", expected ErrNotEncodable`, err)
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	db := openFakeTable(t, NullExpression{}, NullExpression{*MustParse("@hourly"), true})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var results []NullExpression
+	for rows.Next() {
+		var nexpr NullExpression
+		if err = rows.Scan(&nexpr); err != nil {
+			t.Errorf(`rows.Scan() returned "%s"`, err)
+		}
+		results = append(results, nexpr)
+	}
+	if len(results) != 2 || results[0].Valid || !results[1].Valid || results[1].Expression.String() != "0 0 * * * * *" {
+		t.Errorf(`rows.Scan() returned %v`, results)
+	}
+
+	// NULL can't be scanned in a non-nullable Expression
+	var expr Expression
+	if err = db.QueryRow("SELECT").Scan(&expr); err == nil {
+		t.Errorf(`rows.Scan() of NULL into an Expression returned no error`)
+	}
+	if perr, ok := expr.Scan(nil).(*ParseError); !ok || perr.Kind != ErrorScan || perr.Directive != "NULL" {
+		t.Errorf(`Scan(nil) returned "%v", expected an ErrorScan *ParseError`, perr)
+	}
+}
+
+func TestSQLParseError(t *testing.T) {
+	db := openFakeTable(t, "0 0 33 * *")
+	defer db.Close()
+
+	var nexpr NullExpression
+	err := db.QueryRow("SELECT").Scan(&nexpr)
+	if err == nil {
+		t.Fatalf(`rows.Scan() of a malformed cron expression returned no error`)
+	}
+	var expr Expression
+	perr, ok := expr.Scan("0 0 33 * *").(*ParseError)
+	if !ok || perr.Field != "day-of-month" || perr.Directive != "33" {
+		t.Errorf(`Scan("0 0 33 * *") returned "%v", expected a *ParseError`, perr)
+	}
+	if nexpr.Valid {
+		t.Errorf(`rows.Scan() of a malformed cron expression returned a valid NullExpression`)
+	}
+	if perr, ok := expr.Scan(42).(*ParseError); !ok || perr.Kind != ErrorScan || perr.Error() != "cannot scan int into Expression" {
+		t.Errorf(`Scan(42) returned "%v", expected an ErrorScan *ParseError`, perr)
+	}
+}
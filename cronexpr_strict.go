@@ -0,0 +1,130 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_strict.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+// ParseStrict is like Parse, with the WithStrict option applied.
+func ParseStrict(cronLine string) (*Expression, error) {
+	return ParseWithOptions(cronLine, WithStrict())
+}
+
+// WithStrict rejects cron expressions which are well-formed but most likely
+// mistaken, rather than silently accepting them:
+//
+//	0 0 * * * * * x  fields beyond the seventh, see ErrorExtraFields
+//	0 ? * * *        `?` anywhere but as the whole day-of-month or
+//	                 day-of-week field, see ErrorQuestionMark
+//	0 1,1-3 * * *    directives matching the same value, see ErrorOverlap
+//	0 10-20/15 * * * steps which reach no second value, see ErrorStep
+//	2030-2020        a range which matches no value, in the year field, which
+//	                 unlike other fields does not wrap around, see
+//	                 ErrorEmptyRange
+//	0 0 30 2 *       day fields which match no day of the months and years
+//	                 specified, see ErrorUnsatisfiable
+func WithStrict() Option {
+	return func(opts *parseOptions) {
+		opts.strict = true
+	}
+}
+
+/******************************************************************************/
+
+// newExtraFieldsError returns an ErrorExtraFields error about the fields
+// beyond the seventh.
+func newExtraFieldsError(extra []cronField, cronLine string) *ParseError {
+	begin, end := extra[0].beg, extra[len(extra)-1].end
+	return &ParseError{
+		Kind:      ErrorExtraFields,
+		Input:     cronLine,
+		Begin:     begin,
+		End:       end,
+		Directive: cronLine[begin:end],
+	}
+}
+
+// checkStep returns an ErrorStep error if the step of `directive` is larger
+// than its range, i.e. if only its first value is ever matched.
+func checkStep(desc fieldDescriptor, s string, directive *cronDirective) error {
+	if directive.step > desc.unwrap(directive.first, directive.last)-directive.first {
+		return newDirectiveError(ErrorStep, desc, s, directive)
+	}
+	return nil
+}
+
+// checkOverlaps returns an ErrorOverlap error about the first directive of
+// field `s` which matches a value already matched by a preceding directive.
+func checkOverlaps(desc fieldDescriptor, s string, directives []*cronDirective) error {
+	values := make(map[int]bool)
+	others := make(map[cronDirective]bool)
+	for _, directive := range directives {
+		matched := make(map[int]bool)
+		switch directive.kind {
+		case one:
+			populateOne(matched, directive.first)
+		case span, all:
+			populateMany(matched, desc, directive.first, directive.last, directive.step)
+		default:
+			// `L`, `15W`, `5#3`, etc. only overlap with themselves
+			key := cronDirective{kind: directive.kind, first: directive.first, last: directive.last}
+			if others[key] {
+				return newDirectiveError(ErrorOverlap, desc, s, directive)
+			}
+			others[key] = true
+		}
+		for v := range matched {
+			if values[v] {
+				return newDirectiveError(ErrorOverlap, desc, s, directive)
+			}
+			values[v] = true
+		}
+	}
+	return nil
+}
+
+/******************************************************************************/
+
+// checkSatisfiable returns an ErrorUnsatisfiable error if no day of the
+// months and years of `expr` matches its day-of-month and day-of-week fields.
+// The error is about `domField`, or about `dowField` if only the latter is
+// restricted.
+func (expr *Expression) checkSatisfiable(domField, dowField *cronField, cronLine string) error {
+	years := expr.yearList
+	if years == nil {
+		// Every date falls on every day of the week within 28 years, and 2000
+		// is a leap year
+		years = intRange(2000, 2027)
+	}
+	for _, year := range years {
+		for _, month := range expr.monthList {
+			for day := 1; day <= 31; day++ {
+				if expr.isActualDayOfMonth(year, month, day) {
+					return nil
+				}
+			}
+		}
+	}
+	field, desc := domField, domDescriptor
+	if !expr.daysOfMonthRestricted {
+		field, desc = dowField, dowDescriptor
+	}
+	err := &ParseError{
+		Kind:      ErrorUnsatisfiable,
+		Input:     field.s,
+		Field:     desc.name,
+		Begin:     0,
+		End:       len(field.s),
+		Directive: field.s,
+	}
+	return field.relocate(err, cronLine)
+}
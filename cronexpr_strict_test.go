@@ -0,0 +1,90 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_strict_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"testing"
+	"time"
+)
+
+/******************************************************************************/
+
+var strictErrorTests = []parseErrorTest{
+	{"0 0 * * * * * extra", ErrorExtraFields, "", "extra", 14},
+	{"0 0 * * * * * a b", ErrorExtraFields, "", "a b", 14},
+	{"0 ? * * *", ErrorQuestionMark, "hour", "?", 2},
+	{"0 0 ? * ?", ErrorQuestionMark, "day-of-week", "?", 8},
+	{"0 1,1-3 * * *", ErrorOverlap, "hour", "1-3", 4},
+	{"0 0 * * 0,7", ErrorOverlap, "day-of-week", "7", 10},
+	{"0 0 * * *,MON", ErrorOverlap, "day-of-week", "MON", 10},
+	{"0 0 L,L * *", ErrorOverlap, "day-of-month", "L", 6},
+	{"0 10-20/15 * * *", ErrorStep, "hour", "10-20/15", 2},
+	{"50/20 * * * *", ErrorStep, "minute", "50/20", 0},
+	{"0 0 */31 * *", ErrorStep, "day-of-month", "*/31", 4},
+	{"0 0 1 1 * 2030-2020", ErrorEmptyRange, "year", "2030-2020", 10},
+	{"0 0 1 1 * 2000,2030-2020/2", ErrorEmptyRange, "year", "2030-2020/2", 15},
+	{"0 0 30 2 *", ErrorUnsatisfiable, "day-of-month", "30", 4},
+	{"0 0 31 4,6 *", ErrorUnsatisfiable, "day-of-month", "31", 4},
+	{"0 0 29 2 * 2025", ErrorUnsatisfiable, "day-of-month", "29", 4},
+	{"0 0 * 2 5#5 2026", ErrorUnsatisfiable, "day-of-week", "5#5", 8},
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, test := range strictErrorTests {
+		_, err := ParseStrict(test.expr)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Kind != test.kind || perr.Field != test.field || perr.Directive != test.directive || perr.Begin != test.begin {
+			t.Errorf(`ParseStrict("%s") returned "%v", expected %s`, test.expr, err, test.kind)
+			continue
+		}
+		// Not an error by default
+		expr, err := Parse(test.expr)
+		if err != nil {
+			t.Errorf(`Parse("%s") returned "%s"`, test.expr, err)
+			continue
+		}
+		if test.kind == ErrorEmptyRange || test.kind == ErrorUnsatisfiable {
+			if next := expr.Next(time.Now()); !next.IsZero() {
+				t.Errorf(`("%s").Next() = "%s", expected zero time`, test.expr, next)
+			}
+		}
+	}
+
+	accepted := []string{
+		"*/15 * * * *",
+		"@daily",
+		"0 0 1,15 * *",
+		"0 0 L * *",
+		"0 0 29 2 *",
+		"0 0 29 2 * 2024-2025",
+		"0 0 1 1 * 2000-2020", // past years are not checked against the clock
+		"0 0 31W 4,6,7 *",
+		"0 0 30 2 MON", // either day field may match
+		"0 0 * 2 5#5",
+		"0 0 ? * MON",
+		// Wrap-around ranges are not empty
+		"0 22-2 * * *",
+		"0 22-4/2 * * *",
+		"0 0 * * FRI-MON",
+		"0 0 * * 0-7",
+		"0 0 28-2 * *",
+		"0 0 1 NOV-FEB *",
+	}
+	for _, s := range accepted {
+		if _, err := ParseStrict(s); err != nil {
+			t.Errorf(`ParseStrict("%s") returned "%s"`, s, err)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: cronexpr_string.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package cronexpr
+
+/******************************************************************************/
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/******************************************************************************/
+
+// Source returns the cron expression exactly as it was supplied to Parse.
+func (expr *Expression) Source() string {
+	return expr.expression
+}
+
+// String returns the canonical form of the cron expression `expr`, that is, a
+// 7-field cron expression in which lists of values are compressed back into
+// ranges and steps. Equivalent cron expressions, e.g. `@daily`, `0 0 * * *`
+// and `0 0 0 * * * *`, share the same canonical form.
+//
+// If the cron expression has its own time zone, the canonical form is
+// prefixed with `CRON_TZ=` followed by the name of the time zone.
+//
+// The canonical form of a `|`-separated list of cron expressions is the list
+// of the canonical forms of its members. The canonical form of an `@every`
+// schedule is `@every <duration>`, followed by `from <anchor>` unless the
+// anchor is the Unix epoch.
+//
+// Parsing the canonical form yields an Expression firing at the same time
+// instants, provided it is parsed with the same dialect and daylight-saving
+// time policy, neither of which is part of the canonical form, see
+// WithDialect, WithDSTPolicy and WithVixieDST. `H` directives and localized
+// names are recorded as the values they stand for, hence the canonical form
+// needs neither a seed nor a locale.
+func (expr *Expression) String() string {
+	if expr.members != nil {
+		return expr.formatUnion()
+	}
+	if expr.every > 0 {
+		return expr.withLocationPrefix([]string{expr.formatEvery()})
+	}
+	fields := []string{
+		formatList(expr.secondList, secondDescriptor, true),
+		formatList(expr.minuteList, minuteDescriptor, true),
+		formatList(expr.hourList, hourDescriptor, true),
+		expr.formatDaysOfMonth(),
+		formatList(expr.monthList, monthDescriptor, true),
+		expr.formatDaysOfWeek(),
+		formatYears(expr.yearList),
+	}
+	return expr.withLocationPrefix(fields)
+}
+
+func (expr *Expression) withLocationPrefix(fields []string) string {
+	if expr.location != nil {
+		fields = append([]string{"CRON_TZ=" + expr.location.String()}, fields...)
+	}
+	return strings.Join(fields, " ")
+}
+
+/******************************************************************************/
+
+func (expr *Expression) formatDaysOfMonth() string {
+	if expr.daysOfMonthRestricted == false {
+		return "*"
+	}
+	// `*` would lift the restriction, hence `1-31` is kept as is
+	entries := make([]string, 0, 4)
+	if len(expr.daysOfMonth) > 0 {
+		entries = append(entries, formatList(toList(expr.daysOfMonth), domDescriptor, false))
+	}
+	for _, v := range toList(expr.workdaysOfMonth) {
+		entries = append(entries, strconv.Itoa(v)+"W")
+	}
+	if expr.lastDayOfMonth {
+		entries = append(entries, "L")
+	}
+	if expr.lastWorkdayOfMonth {
+		entries = append(entries, "LW")
+	}
+	return strings.Join(entries, ",")
+}
+
+func (expr *Expression) formatDaysOfWeek() string {
+	if expr.daysOfWeekRestricted == false {
+		return "*"
+	}
+	// Quartz numbers days of week from 1 (Sunday)
+	desc, first := dowDescriptor, 0
+	if expr.dialect == DialectQuartz {
+		desc.min, desc.max, desc.origin, first = 1, 7, 1, 1
+	}
+	entries := make([]string, 0, 4)
+	if len(expr.daysOfWeek) > 0 {
+		days := toList(expr.daysOfWeek)
+		for i := range days {
+			days[i] += first
+		}
+		entries = append(entries, formatList(days, desc, false))
+	}
+	for _, v := range toList(expr.lastWeekDaysOfWeek) {
+		entries = append(entries, strconv.Itoa(v+first)+"L")
+	}
+	// Keys are `(week-1)*7 + day-of-week`, see dowFieldHandler()
+	specifics := toList(expr.specificWeekDaysOfWeek)
+	sort.Slice(specifics, func(i, j int) bool {
+		if specifics[i]%7 != specifics[j]%7 {
+			return specifics[i]%7 < specifics[j]%7
+		}
+		return specifics[i] < specifics[j]
+	})
+	for _, v := range specifics {
+		entries = append(entries, strconv.Itoa(v%7+first)+"#"+strconv.Itoa(v/7+1))
+	}
+	return strings.Join(entries, ",")
+}
+
+// formatYears formats the list of years, nil standing for any year.
+func formatYears(list []int) string {
+	if list == nil {
+		return "*"
+	}
+	// A reversed range, which matches no year, see WithStrict
+	if len(list) == 0 {
+		return "9999-1"
+	}
+	// Years of fewer than four digits must be part of a range, see
+	// ErrorShortYear
+	i := sort.SearchInts(list, shortYearMax)
+	var entries []string
+	if i > 0 {
+		for _, entry := range strings.Split(formatList(list[:i], yearDescriptor, false), ",") {
+			if !strings.Contains(entry, "-") {
+				entry += "-" + entry
+			}
+			entries = append(entries, entry)
+		}
+	}
+	if i < len(list) {
+		entries = append(entries, formatList(list[i:], yearDescriptor, false))
+	}
+	return strings.Join(entries, ",")
+}
+
+/******************************************************************************/
+
+// formatList compresses a sorted list of values into the shortest cron
+// directives this package knows how to produce: `*`, `*/step`, `first-last`,
+// `first-last/step` and single values.
+func formatList(list []int, desc fieldDescriptor, wildcard bool) string {
+	n := len(list)
+	if n == 0 {
+		return ""
+	}
+	// `*`
+	if wildcard && n == desc.max-desc.min+1 && list[0] == desc.min && list[n-1] == desc.max {
+		return "*"
+	}
+	// `*/step`, for at least three values: `0,45` reads better as a list
+	if n > 2 && list[0] == desc.origin {
+		step := list[1] - list[0]
+		if step > 1 && list[n-1]+step > desc.max && isProgression(list, step) {
+			return "*/" + strconv.Itoa(step)
+		}
+	}
+	entries := make([]string, 0, n)
+	for i := 0; i < n; {
+		// Look for the longest arithmetic progression starting at `i`
+		j := i
+		if i+1 < n {
+			step := list[i+1] - list[i]
+			for j+1 < n && list[j+1]-list[j] == step {
+				j += 1
+			}
+		}
+		if j-i+1 < 3 {
+			entries = append(entries, strconv.Itoa(list[i]))
+			i += 1
+			continue
+		}
+		entry := strconv.Itoa(list[i]) + "-" + strconv.Itoa(list[j])
+		if step := list[i+1] - list[i]; step > 1 {
+			entry += "/" + strconv.Itoa(step)
+		}
+		entries = append(entries, entry)
+		i = j + 1
+	}
+	return strings.Join(entries, ",")
+}
+
+func isProgression(list []int, step int) bool {
+	for i := 1; i < len(list); i++ {
+		if list[i]-list[i-1] != step {
+			return false
+		}
+	}
+	return true
+}
@@ -15,6 +15,8 @@ package cronexpr
 /******************************************************************************/
 
 import (
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -194,6 +196,72 @@ var crontests = []crontest{
 		},
 	},
 
+	// Years beyond 2099 and before 1970
+	{
+		"0 0 29 2 *",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2096-03-01 00:00:00", "Fri 2104-02-29 00:00"},
+			{"2399-03-01 00:00:00", "Tue 2400-02-29 00:00"},
+			{"9996-03-01 00:00:00", "Tue 10000-02-29 00:00"},
+		},
+	},
+	{
+		"0 0 1 1 * 1800,1900,2150,9999",
+		"2006-01-02 15:04",
+		[]crontimes{
+			{"1899-06-01 00:00:00", "1900-01-01 00:00"},
+			{"2013-06-01 00:00:00", "2150-01-01 00:00"},
+			{"2150-06-01 00:00:00", "9999-01-01 00:00"},
+		},
+	},
+	{
+		"0 0 1 1 * */50",
+		"2006-01-02 15:04",
+		[]crontimes{
+			{"2013-06-01 00:00:00", "2020-01-01 00:00"},
+			{"2090-06-01 00:00:00", "2120-01-01 00:00"},
+		},
+	},
+
+	// Wrap-around ranges
+	{
+		"0 22-4/2 * * *",
+		"2006-01-02 15:04",
+		[]crontimes{
+			{"2013-01-01 21:00:00", "2013-01-01 22:00"},
+			{"2013-01-01 22:00:00", "2013-01-02 00:00"},
+			{"2013-01-02 00:00:00", "2013-01-02 02:00"},
+			{"2013-01-02 04:00:00", "2013-01-02 22:00"},
+		},
+	},
+	{
+		"0 9 * * FRI-MON",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-01-01 00:00:00", "Fri 2013-01-04 09:00"},
+			{"2013-01-04 09:00:00", "Sat 2013-01-05 09:00"},
+			{"2013-01-07 09:00:00", "Fri 2013-01-11 09:00"},
+		},
+	},
+	{
+		"0 0 * * 5-7",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-01-01 00:00:00", "Fri 2013-01-04 00:00"},
+			{"2013-01-06 00:00:00", "Fri 2013-01-11 00:00"},
+		},
+	},
+	{
+		"0 0 28-2 * *",
+		"2006-01-02 15:04",
+		[]crontimes{
+			{"2013-02-03 00:00:00", "2013-02-28 00:00"},
+			{"2013-02-28 00:00:00", "2013-03-01 00:00"},
+			{"2013-03-02 00:00:00", "2013-03-28 00:00"},
+		},
+	},
+
 	// TODO: more tests
 }
 
@@ -228,6 +296,16 @@ func TestZero(t *testing.T) {
 		t.Error(`("* * * * * 2050").Next("2013-08-31").IsZero() returned 'true', expected 'false'`)
 	}
 
+	// Never fires, the search must give up
+	next = MustParse("0 0 31 2 *").Next(from)
+	if next.IsZero() == false {
+		t.Error(`("0 0 31 2 *").Next("2013-08-31").IsZero() returned 'false', expected 'true'`)
+	}
+	next = MustParse("0 0 30 2 * 2000-9999").Prev(from)
+	if next.IsZero() == false {
+		t.Error(`("0 0 30 2 * 2000-9999").Prev("2013-08-31").IsZero() returned 'false', expected 'true'`)
+	}
+
 	next = MustParse("* * * * * 2099").Next(time.Time{})
 	if next.IsZero() == false {
 		t.Error(`("* * * * * 2014").Next(time.Time{}).IsZero() returned 'true', expected 'false'`)
@@ -282,6 +360,319 @@ func TestNextN_every5min(t *testing.T) {
 	}
 }
 
+var prevtests = []crontest{
+	// Seconds
+	{
+		"* * * * * * *",
+		"2006-01-02 15:04:05",
+		[]crontimes{
+			{"2013-01-01 00:00:01", "2013-01-01 00:00:00"},
+			{"2013-01-01 00:01:00", "2013-01-01 00:00:59"},
+			{"2013-01-02 00:00:00", "2013-01-01 23:59:59"},
+			{"2013-03-01 00:00:00", "2013-02-28 23:59:59"},
+			{"2016-03-01 00:00:00", "2016-02-29 23:59:59"},
+			{"2013-01-01 00:00:00", "2012-12-31 23:59:59"},
+		},
+	},
+
+	// Minutes interval, list
+	{
+		"15-30/4,55 * * * *",
+		"2006-01-02 15:04:05",
+		[]crontimes{
+			{"2013-01-01 00:16:00", "2013-01-01 00:15:00"},
+			{"2013-01-01 00:55:00", "2013-01-01 00:27:00"},
+			{"2013-01-01 01:15:00", "2013-01-01 00:55:00"},
+			{"2013-01-01 00:15:00", "2012-12-31 23:55:00"},
+		},
+	},
+
+	// Specific days of week
+	{
+		"0 0 * * 6#5",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-11-30 00:00:00", "Sat 2013-08-31 00:00"},
+		},
+	},
+
+	// Last days of week
+	{
+		"0 0 * * 5L",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-09-27 00:00:00", "Fri 2013-08-30 00:00"},
+			{"2013-09-27 00:00:01", "Fri 2013-09-27 00:00"},
+		},
+	},
+
+	// Work day of month
+	{
+		"0 0 15W * *",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-09-30 00:00:00", "Mon 2013-09-16 00:00"},
+			{"2013-06-15 00:00:00", "Fri 2013-06-14 00:00"},
+		},
+	},
+
+	// Last day of month
+	{
+		"0 0 L * *",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-09-30 00:00:00", "Sat 2013-08-31 00:00"},
+			{"2016-03-15 00:00:00", "Mon 2016-02-29 00:00"},
+		},
+	},
+
+	// Last work day of month
+	{
+		"0 0 LW * *",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2013-12-02 00:00:00", "Fri 2013-11-29 00:00"},
+			{"2014-09-15 00:00:00", "Fri 2014-08-29 00:00"},
+		},
+	},
+
+	// Leap day
+	{
+		"0 0 29 2 *",
+		"Mon 2006-01-02 15:04",
+		[]crontimes{
+			{"2019-06-01 00:00:00", "Mon 2016-02-29 00:00"},
+			{"2104-01-01 00:00:00", "Wed 2096-02-29 00:00"},
+			{"1904-01-01 00:00:00", "Sat 1896-02-29 00:00"},
+		},
+	},
+}
+
+func TestPrevExpressions(t *testing.T) {
+	for _, test := range prevtests {
+		for _, times := range test.times {
+			from, _ := time.Parse("2006-01-02 15:04:05", times.from)
+			expr, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf(`Parse("%s") returned "%s"`, test.expr, err.Error())
+			}
+			prev := expr.Prev(from)
+			prevstr := prev.Format(test.layout)
+			if prevstr != times.next {
+				t.Errorf(`("%s").Prev("%s") = "%s", got "%s"`, test.expr, times.from, times.next, prevstr)
+			}
+		}
+	}
+}
+
+// Prev() must walk back to the closest match, hence stepping forward again
+// from the result must lead back to where we started.
+func TestPrevNextRoundTrip(t *testing.T) {
+	for _, test := range crontests {
+		for _, times := range test.times {
+			from, _ := time.Parse("2006-01-02 15:04:05", times.from)
+			expr := MustParse(test.expr)
+			next := expr.Next(from)
+			prev := expr.Prev(next)
+			if prev.IsZero() || !expr.Next(prev).Equal(next) {
+				t.Errorf(`("%s").Next(("%s").Prev("%s")) = "%s", expected "%s"`, test.expr, test.expr, next, expr.Next(prev), next)
+			}
+		}
+	}
+}
+
+func TestPrevZero(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2013-08-31")
+	prev := MustParse("* * * * * 2050").Prev(from)
+	if prev.IsZero() == false {
+		t.Error(`("* * * * * 2050").Prev("2013-08-31").IsZero() returned 'false', expected 'true'`)
+	}
+
+	prev = MustParse("* * * * * 1980").Prev(from)
+	if prev.Format("2006-01-02 15:04:05") != "1980-12-31 23:59:00" {
+		t.Errorf(`("* * * * * 1980").Prev("2013-08-31") = "%s", expected "1980-12-31 23:59:00"`, prev)
+	}
+
+	prev = MustParse("* * * * * * *").Prev(time.Time{})
+	if prev.IsZero() == false {
+		t.Error(`("* * * * * * *").Prev(time.Time{}).IsZero() returned 'false', expected 'true'`)
+	}
+
+	prev = MustParse("* * * * * * *").Prev(from.Add(500 * time.Millisecond))
+	if !prev.Equal(from) {
+		t.Errorf(`("* * * * * * *").Prev("2013-08-31 00:00:00.5") = "%s", expected "%s"`, prev, from)
+	}
+}
+
+func TestPrevN(t *testing.T) {
+	expected := []string{
+		"Sat, 30 Nov 2013 00:00:00",
+		"Sat, 31 Aug 2013 00:00:00",
+		"Sat, 29 Jun 2013 00:00:00",
+		"Sat, 30 Mar 2013 00:00:00",
+		"Sat, 29 Dec 2012 00:00:00",
+	}
+	from, _ := time.Parse("2006-01-02 15:04:05", "2013-12-02 08:44:30")
+	result := MustParse("0 0 * * 6#5").PrevN(from, uint(len(expected)))
+	if len(result) != len(expected) {
+		t.Errorf(`MustParse("0 0 * * 6#5").PrevN("2013-12-02 08:44:30", 5):\n"`)
+		t.Errorf(`  Expected %d returned time values but got %d instead`, len(expected), len(result))
+	}
+	for i, prev := range result {
+		prevStr := prev.Format("Mon, 2 Jan 2006 15:04:05")
+		if prevStr != expected[i] {
+			t.Errorf(`MustParse("0 0 * * 6#5").PrevN("2013-12-02 08:44:30", 5):\n"`)
+			t.Errorf(`  result[%d]: expected "%s" but got "%s"`, i, expected[i], prevStr)
+		}
+	}
+}
+
+// On the day clocks are set forward, the local times of the gap do not exist:
+// Prev must step back past the gap rather than return a later time instant.
+func TestPrevSpringForward(t *testing.T) {
+	tests := []struct {
+		zone     string
+		from     string
+		expected []string
+	}{
+		{"Europe/Berlin", "2025-03-30T03:00:00+02:00", []string{
+			"2025-03-30T01:59:00+01:00",
+			"2025-03-30T01:58:00+01:00",
+			"2025-03-30T01:57:00+01:00",
+		}},
+		{"Europe/Berlin", "2025-03-30T03:01:00+02:00", []string{
+			"2025-03-30T03:00:00+02:00",
+			"2025-03-30T01:59:00+01:00",
+			"2025-03-30T01:58:00+01:00",
+		}},
+		{"America/New_York", "2025-03-09T03:00:00-04:00", []string{
+			"2025-03-09T01:59:00-05:00",
+			"2025-03-09T01:58:00-05:00",
+			"2025-03-09T01:57:00-05:00",
+		}},
+	}
+	expr := MustParse("* * * * *")
+	for _, test := range tests {
+		loc, _ := time.LoadLocation(test.zone)
+		from, _ := time.Parse(time.RFC3339, test.from)
+		var actual []string
+		for _, prev := range expr.PrevN(from.In(loc), uint(len(test.expected))) {
+			actual = append(actual, prev.Format(time.RFC3339))
+		}
+		if !equalStrings(actual, test.expected) {
+			t.Errorf(`("* * * * *").PrevN("%s" %s) = %q, expected %q`, test.from, test.zone, actual, test.expected)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	for _, test := range crontests {
+		expr := MustParse(test.expr)
+		for _, times := range test.times {
+			from, _ := time.Parse("2006-01-02 15:04:05", times.from)
+			next := expr.Next(from)
+			if expr.Matches(next) == false {
+				t.Errorf(`("%s").Matches("%s") returned 'false', expected 'true'`, test.expr, next)
+			}
+			if expr.Matches(next.Add(time.Millisecond)) {
+				t.Errorf(`("%s").Matches("%s") returned 'true', expected 'false'`, test.expr, next.Add(time.Millisecond))
+			}
+			expected := expr.Next(from.Add(-time.Second)).Equal(from)
+			if expr.Matches(from) != expected {
+				t.Errorf(`("%s").Matches("%s") returned '%v', expected '%v'`, test.expr, times.from, !expected, expected)
+			}
+		}
+	}
+}
+
+func TestMatchesAllocs(t *testing.T) {
+	from, _ := time.Parse("2006-01-02 15:04:05", "2013-11-29 00:00:00")
+	for _, test := range crontests {
+		expr := MustParse(test.expr)
+		allocs := testing.AllocsPerRun(100, func() {
+			expr.Matches(from)
+		})
+		if allocs != 0 {
+			t.Errorf(`("%s").Matches() allocated %v times, expected none`, test.expr, allocs)
+		}
+	}
+}
+
+// Run with `go test -race` to have the race detector validate that a shared
+// Expression is never written to while evaluated.
+func TestConcurrentNext(t *testing.T) {
+	exprs := make([]*Expression, benchmarkExpressionsLen)
+	for i := range exprs {
+		exprs[i] = MustParse(benchmarkExpressions[i])
+	}
+	// Month boundaries force actual days of month to be recalculated
+	from, _ := time.Parse("2006-01-02 15:04:05", "2013-01-28 23:59:59")
+	expected := make([][]time.Time, len(exprs))
+	for i, expr := range exprs {
+		expected[i] = expr.NextN(from, 50)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for n := 0; n < 20; n++ {
+				i := (g + n) % len(exprs)
+				expr := exprs[i]
+				result := expr.NextN(from, 50)
+				if len(result) != len(expected[i]) {
+					t.Errorf(`("%s").NextN() returned %d time values, expected %d`, benchmarkExpressions[i], len(result), len(expected[i]))
+					return
+				}
+				for j := range result {
+					if !result[j].Equal(expected[i][j]) {
+						t.Errorf(`("%s").NextN()[%d] = "%s", expected "%s"`, benchmarkExpressions[i], j, result[j], expected[i][j])
+						return
+					}
+				}
+				next := from
+				for j := range expected[i] {
+					next = expr.Next(next)
+					if !next.Equal(expected[i][j]) {
+						t.Errorf(`("%s").Next() = "%s", expected "%s"`, benchmarkExpressions[i], next, expected[i][j])
+						return
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestBetween(t *testing.T) {
+	from, _ := time.Parse("2006-01-02 15:04:05", "2013-09-02 08:45:00")
+	to, _ := time.Parse("2006-01-02 15:04:05", "2013-09-02 09:05:00")
+	expr := MustParse("*/5 * * * *")
+	expected := expr.NextN(from.Add(-time.Second), 4)
+	result := expr.Between(from, to)
+	if len(result) != len(expected) {
+		t.Fatalf(`("*/5 * * * *").Between() returned %d time values, expected %d`, len(result), len(expected))
+	}
+	for i := range result {
+		if !result[i].Equal(expected[i]) {
+			t.Errorf(`("*/5 * * * *").Between()[%d] = "%s", expected "%s"`, i, result[i], expected[i])
+		}
+	}
+
+	// Across years, with no more matching time instants beyond 2015
+	from, _ = time.Parse("2006-01-02", "2013-01-01")
+	to, _ = time.Parse("2006-01-02", "2099-01-01")
+	result = MustParse("0 0 29 2 * 2012-2015,2016").Between(from, to)
+	if len(result) != 1 || result[0].Format("2006-01-02") != "2016-02-29" {
+		t.Errorf(`("0 0 29 2 * 2012-2015,2016").Between() = %v, expected [2016-02-29]`, result)
+	}
+
+	if result = expr.Between(to, from); len(result) != 0 {
+		t.Errorf(`Between() of an empty range returned %d time values`, len(result))
+	}
+}
+
 // Issue: https://github.com/gorhill/cronexpr/issues/16
 func TestInterval_Interval60Issue(t *testing.T) {
 	_, err := Parse("*/60 * * * * *")
@@ -307,6 +698,206 @@ func TestInterval_Interval60Issue(t *testing.T) {
 
 /******************************************************************************/
 
+type parseErrorTest struct {
+	expr      string
+	kind      ErrorKind
+	field     string
+	directive string
+	begin     int
+}
+
+var parseErrorTests = []parseErrorTest{
+	{"* * * *", ErrorMissingFields, "", "", 7},
+	{"0 0 * * x", ErrorSyntax, "day-of-week", "x", 8},
+	{"0 0 * * MON,5X", ErrorSyntax, "day-of-week", "5X", 12},
+	{"0 0 33 * *", ErrorSyntax, "day-of-month", "33", 4},
+	{"0 0 1,L,2Q * *", ErrorSyntax, "day-of-month", "2Q", 8},
+	{"  */0   * * * *", ErrorInterval, "minute", "*/0", 2},
+	{"0 0 0 1-5/99 * * *", ErrorInterval, "day-of-month", "1-5/99", 6},
+	{"0 0 0 * * * 10000", ErrorSyntax, "year", "10000", 12},
+	{"0 0 12 * * 1", ErrorShortYear, "year", "1", 11},
+	{"0 0 0 * * * 1/5", ErrorShortYear, "year", "1/5", 12},
+	{"0 0 0 * * * 1969,999", ErrorShortYear, "year", "999", 17},
+	{"0 , * * *", ErrorMissingDirective, "hour", ",", 2},
+	{"0\t0 * Jan-Foo *", ErrorSyntax, "month", "Jan-Foo", 6},
+}
+
+func TestParseError(t *testing.T) {
+	for _, test := range parseErrorTests {
+		_, err := Parse(test.expr)
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf(`Parse("%s") returned "%v", expected a *ParseError`, test.expr, err)
+			continue
+		}
+		if perr.Kind != test.kind || perr.Field != test.field || perr.Directive != test.directive || perr.Begin != test.begin {
+			t.Errorf(`Parse("%s") returned {%s, %q, %q, %d}, expected {%s, %q, %q, %d}`,
+				test.expr,
+				perr.Kind, perr.Field, perr.Directive, perr.Begin,
+				test.kind, test.field, test.directive, test.begin)
+		}
+		if perr.Input[perr.Begin:perr.End] != perr.Directive {
+			t.Errorf(`Parse("%s") returned offsets [%d:%d] which do not match "%s"`, test.expr, perr.Begin, perr.End, perr.Directive)
+		}
+	}
+}
+
+func TestParseErrorPretty(t *testing.T) {
+	_, err := Parse("0 0 * * MON,FRIX")
+	expected := "0 0 * * MON,FRIX\n" +
+		"            ^~~~\n" +
+		"syntax error in day-of-week field: 'FRIX'"
+	if pretty := err.(*ParseError).Pretty(); pretty != expected {
+		t.Errorf("Pretty() returned:\n%s\nexpected:\n%s", pretty, expected)
+	}
+}
+
+/******************************************************************************/
+
+var stringTests = []struct {
+	expr      string
+	canonical string
+}{
+	{"@daily", "0 0 0 * * * *"},
+	{"0 0 * * *", "0 0 0 * * * *"},
+	{"0 0 0 * * * *", "0 0 0 * * * *"},
+	{"@hourly", "0 0 * * * * *"},
+	{"*/5 * * * *", "0 */5 * * * * *"},
+	{"0-55/5 * * * *", "0 */5 * * * * *"},
+	{"15-30/4,55 * * * *", "0 15-27/4,55 * * * * *"},
+	{"1,2,3,4,10 0 * * *", "0 1-4,10 0 * * * *"},
+	{"0 0 1-31 * *", "0 0 0 1-31 * * *"},
+	{"0 0 * * 0-6", "0 0 0 * * 0-6 *"},
+	{"0 0 * * 7,MON,tue", "0 0 0 * * 0-2 *"},
+	{"0,45 0 * * 0,6", "0 0,45 0 * * 0,6 *"},
+	{"*/30 * * * *", "0 0,30 * * * * *"},
+	{"*/20 * * * *", "0 */20 * * * * *"},
+	{"30 3 15W 3/3 *", "0 30 3 15W 3-12/3 * *"},
+	{"0 0 L,LW,1 * *", "0 0 0 1,L,LW * * *"},
+	{"0 0 * * 5L,thu#3,6#5", "0 0 0 * * 5L,4#3,6#5 *"},
+	{"0 0 * * 5L,5", "0 0 0 * * 5,5L *"},
+	{"30 0 0 1-31/5 Oct-Dec * 2000,2006,2008,2013-2015", "30 0 0 */5 10-12 * 2000,2006,2008,2013-2015"},
+	{"0 0 0 * Feb-Nov/2 thu#3 2000-2050", "0 0 0 * 2-10/2 4#3 2000-2050"},
+	{"0 0 * * * 1900,2150,9999", "0 0 0 * * * 1900,2150,9999"},
+	{"0 0 * * * 1-1,99-99,1969", "0 0 0 * * * 1-1,99-99,1969"},
+	{"0 0 * * * */5", "0 0 0 * * * */5"},
+	{"0 0 * * * 1970-9999", "0 0 0 * * * 1970-9999"},
+	{"0 0 1 01 07", "0 0 0 1 1 0 *"},
+	{"0 22-2 * * *", "0 0 0-2,22,23 * * * *"},
+	{"0 0 * * FRI-MON", "0 0 0 * * 0,1,5,6 *"},
+	{"0 0 * * 5-7", "0 0 0 * * 0,5,6 *"},
+	{"0 0 * * 0-7", "0 0 0 * * 0-6 *"},
+	{"0 0 * * 7-7", "0 0 0 * * 0 *"},
+	{"0 0 1 NOV-FEB *", "0 0 0 1 1,2,11,12 * *"},
+	{"50-10/5 * * * * * *", "0-10/5,50,55 * * * * * *"},
+	{"0 0 1 1 * 2030-2020", "0 0 0 1 1 * 9999-1"},
+}
+
+func TestString(t *testing.T) {
+	for _, test := range stringTests {
+		expr := MustParse(test.expr)
+		if expr.Source() != test.expr {
+			t.Errorf(`("%s").Source() = "%s"`, test.expr, expr.Source())
+		}
+		canonical := expr.String()
+		if canonical != test.canonical {
+			t.Errorf(`("%s").String() = "%s", expected "%s"`, test.expr, canonical, test.canonical)
+			continue
+		}
+		if again := MustParse(canonical).String(); again != canonical {
+			t.Errorf(`("%s").String() = "%s", expected "%s"`, canonical, again, canonical)
+		}
+	}
+}
+
+// Parsing the canonical form must yield an equivalent expression.
+func TestStringRoundTrip(t *testing.T) {
+	for _, test := range crontests {
+		expr := MustParse(test.expr)
+		canonical := MustParse(expr.String())
+		for _, times := range test.times {
+			from, _ := time.Parse("2006-01-02 15:04:05", times.from)
+			for _, next := range expr.NextN(from, 10) {
+				if !canonical.Matches(next) {
+					t.Errorf(`("%s").Matches("%s") returned 'false', expected 'true'`, canonical, next)
+				}
+			}
+			if !expr.Next(from).Equal(canonical.Next(from)) {
+				t.Errorf(`("%s").Next("%s") != ("%s").Next("%s")`, test.expr, times.from, canonical, times.from)
+			}
+		}
+	}
+}
+
+/******************************************************************************/
+
+func TestLocation(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+
+	expr := MustParse("CRON_TZ=America/New_York 0 9 * * *")
+	if expr.Location() == nil || expr.Location().String() != "America/New_York" {
+		t.Errorf(`("%s").Location() = %v, expected America/New_York`, expr.Source(), expr.Location())
+	}
+	next := expr.Next(from)
+	if s := next.Format(time.RFC3339); s != "2024-01-01T09:00:00-05:00" {
+		t.Errorf(`("%s").Next("2024-01-01T00:00:00Z") = "%s", expected "2024-01-01T09:00:00-05:00"`, expr.Source(), s)
+	}
+	if next.Location() != expr.Location() {
+		t.Errorf(`("%s").Next() returned a time value in %s`, expr.Source(), next.Location())
+	}
+	if !expr.Matches(next.UTC()) || expr.Matches(from.Add(9*time.Hour)) {
+		t.Errorf(`("%s").Matches() did not evaluate time values in America/New_York`, expr.Source())
+	}
+	if prev := expr.Prev(from).Format(time.RFC3339); prev != "2023-12-31T09:00:00-05:00" {
+		t.Errorf(`("%s").Prev("2024-01-01T00:00:00Z") = "%s", expected "2023-12-31T09:00:00-05:00"`, expr.Source(), prev)
+	}
+
+	expr = MustParse("TZ=Asia/Tokyo @daily")
+	if s := expr.String(); s != "CRON_TZ=Asia/Tokyo 0 0 0 * * * *" {
+		t.Errorf(`("%s").String() = "%s", expected "CRON_TZ=Asia/Tokyo 0 0 0 * * * *"`, expr.Source(), s)
+	}
+	if next := expr.Next(from).Format(time.RFC3339); next != "2024-01-02T00:00:00+09:00" {
+		t.Errorf(`("%s").Next("2024-01-01T00:00:00Z") = "%s", expected "2024-01-02T00:00:00+09:00"`, expr.Source(), next)
+	}
+
+	// No time zone: time values stay in their own location
+	if MustParse("0 9 * * *").Location() != nil {
+		t.Errorf(`("0 9 * * *").Location() returned non-nil`)
+	}
+}
+
+func TestParseInLocation(t *testing.T) {
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	paris, _ := time.LoadLocation("Europe/Paris")
+
+	expr, err := ParseInLocation("0 9 * * *", paris)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := expr.Next(from).Format(time.RFC3339); next != "2024-01-01T09:00:00+01:00" {
+		t.Errorf(`ParseInLocation("0 9 * * *", Europe/Paris).Next() = "%s", expected "2024-01-01T09:00:00+01:00"`, next)
+	}
+
+	// The prefix has precedence
+	expr, err = ParseInLocation("CRON_TZ=UTC 0 9 * * *", paris)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := expr.Next(from).Format(time.RFC3339); next != "2024-01-01T09:00:00Z" {
+		t.Errorf(`ParseInLocation("CRON_TZ=UTC 0 9 * * *", Europe/Paris).Next() = "%s", expected "2024-01-01T09:00:00Z"`, next)
+	}
+
+	for _, cronLine := range []string{"CRON_TZ=Mars/Olympus_Mons 0 9 * * *", "TZ= 0 9 * * *"} {
+		_, err = Parse(cronLine)
+		perr, ok := err.(*ParseError)
+		if !ok || perr.Kind != ErrorTimeZone || perr.Begin != 0 || perr.Directive != cronLine[:strings.Index(cronLine, " ")] {
+			t.Errorf(`Parse("%s") returned "%v", expected an unknown time zone error`, cronLine, err)
+		}
+	}
+}
+
+/******************************************************************************/
+
 var benchmarkExpressions = []string{
 	"* * * * *",
 	"@hourly",
@@ -0,0 +1,227 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: crontab.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+// Package crontab parses whole crontab files, i.e. user crontabs as edited
+// with `crontab -e` and system crontabs such as `/etc/crontab` and the files
+// of `/etc/cron.d`, which have a user column.
+package crontab
+
+/******************************************************************************/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+/******************************************************************************/
+
+// Format tells whether the entries of a crontab have a user column.
+type Format int
+
+const (
+	// User: entries are made of a schedule and a command, as in a crontab
+	// edited with `crontab -e`.
+	User Format = iota
+	// System: entries are made of a schedule, a user and a command, as in
+	// `/etc/crontab` and the files of `/etc/cron.d`.
+	System
+)
+
+// An Entry is a job of a crontab.
+type Entry struct {
+	// Line is the number of the line of the entry, starting at 1.
+	Line int
+	// Text is the line of the entry, verbatim.
+	Text string
+	// Expression is the schedule of the entry, nil for `@reboot`.
+	Expression *cronexpr.Expression
+	// Reboot tells whether the entry runs at startup, i.e. `@reboot`.
+	Reboot bool
+	// User is the user the command runs as, empty in a user crontab.
+	User string
+	// Command is the command, up to the first unescaped `%`, with `\%`
+	// standing for `%`.
+	Command string
+	// Stdin is the standard input of the command, i.e. what follows the
+	// first unescaped `%`, each further unescaped `%` standing for a newline.
+	Stdin string
+	// Env holds the environment assignments in effect for the entry, e.g.
+	// MAILTO or SHELL. It is shared with other entries and must not be
+	// modified.
+	Env map[string]string
+}
+
+// A LineError tells why a line of a crontab was rejected.
+type LineError struct {
+	// Line is the number of the offending line, starting at 1.
+	Line int
+	// Err is a *cronexpr.ParseError for a malformed entry.
+	Err error
+}
+
+func (err *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Err)
+}
+
+func (err *LineError) Unwrap() error {
+	return err.Err
+}
+
+// A Crontab is the result of parsing a crontab file.
+type Crontab struct {
+	Entries []*Entry
+	// Env holds the environment assignments in effect at the end of the
+	// file.
+	Env map[string]string
+	// Errors holds the lines which were rejected, in order.
+	Errors []*LineError
+}
+
+/******************************************************************************/
+
+var (
+	assignmentFinder = regexp.MustCompile(`^\s*("[^"]*"|'[^']*'|[A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*?)\s*$`)
+	rebootFinder     = regexp.MustCompile(`^\s*@reboot(?:\s+|$)`)
+)
+
+// Load parses the crontab file `name` in the format `format`, see Parse.
+func Load(name string, format Format) (*Crontab, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f, format)
+}
+
+// Parse parses a crontab in the format `format`. Blank lines and lines whose
+// first non-blank character is `#` are ignored. A `NAME=value` line assigns
+// an environment variable for the entries which follow, the name and the
+// value being optionally quoted. An assignment of CRON_TZ sets the time zone
+// in which the schedules which follow are evaluated, an empty one resets it.
+//
+// A malformed line does not prevent the other lines from being parsed, it is
+// reported in the Errors of the Crontab. An error is returned only if `r`
+// cannot be read.
+func Parse(r io.Reader, format Format) (*Crontab, error) {
+	crontab := &Crontab{Env: map[string]string{}}
+	var options []cronexpr.Option
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+		// `NAME=value`
+		if match := assignmentFinder.FindStringSubmatch(text); match != nil {
+			name, value := unquote(match[1]), unquote(match[2])
+			if name == "CRON_TZ" && value == "" {
+				options = nil
+			} else if name == "CRON_TZ" {
+				loc, err := time.LoadLocation(value)
+				if err != nil {
+					crontab.Errors = append(crontab.Errors, &LineError{n, err})
+					continue
+				}
+				options = []cronexpr.Option{cronexpr.WithLocation(loc)}
+			}
+			env := make(map[string]string, len(crontab.Env)+1)
+			for k, v := range crontab.Env {
+				env[k] = v
+			}
+			env[name] = value
+			crontab.Env = env
+			continue
+		}
+		entry, err := parseEntry(text, format, options)
+		if err != nil {
+			crontab.Errors = append(crontab.Errors, &LineError{n, err})
+			continue
+		}
+		entry.Line, entry.Text, entry.Env = n, text, crontab.Env
+		crontab.Entries = append(crontab.Entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return crontab, nil
+}
+
+/******************************************************************************/
+
+func parseEntry(text string, format Format, options []cronexpr.Option) (*Entry, error) {
+	layout := cronexpr.LayoutVixie
+	if format == System {
+		layout = cronexpr.LayoutSystem
+	}
+	entry := &Entry{}
+	var command string
+	// `@reboot` is not a schedule, yet the rest of the line is laid out the
+	// same, hence an alias of the same length stands in for it
+	if rebootFinder.MatchString(text) {
+		l, err := cronexpr.ParseLine(strings.Replace(text, "@reboot", "@yearly", 1), layout)
+		if err != nil {
+			if perr, ok := err.(*cronexpr.ParseError); ok {
+				perr.Input = text
+			}
+			return nil, err
+		}
+		entry.Reboot, entry.User, command = true, l.User, text[l.CommandBegin:l.CommandEnd]
+	} else {
+		l, err := cronexpr.ParseLine(text, layout, options...)
+		if err != nil {
+			return nil, err
+		}
+		entry.Expression, entry.User, command = l.Expression, l.User, l.Command
+	}
+	entry.Command, entry.Stdin = splitCommand(command)
+	return entry, nil
+}
+
+// splitCommand applies the `%` semantics of cron to `command`: the first
+// unescaped `%` ends the command, and further ones stand for newlines in the
+// standard input of the command.
+func splitCommand(command string) (string, string) {
+	var parts [2]strings.Builder
+	part := 0
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == '\\' && i+1 < len(command) && command[i+1] == '%':
+			parts[part].WriteByte('%')
+			i += 1
+		case c == '%' && part == 0:
+			part = 1
+		case c == '%':
+			parts[part].WriteByte('\n')
+		default:
+			parts[part].WriteByte(c)
+		}
+	}
+	return parts[0].String(), parts[1].String()
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,154 @@
+/*!
+ * Copyright 2013 Raymond Hill
+ *
+ * Project: github.com/gorhill/cronexpr
+ * File: crontab_test.go
+ * Version: 1.0
+ * License: pick the one which suits you best:
+ *   GPL v3 see <https://www.gnu.org/licenses/gpl.html>
+ *   APL v2 see <http://www.apache.org/licenses/LICENSE-2.0>
+ *
+ */
+
+package crontab
+
+/******************************************************************************/
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+/******************************************************************************/
+
+const systemCrontab = `# /etc/crontab: system-wide crontab
+SHELL=/bin/sh
+PATH = "/usr/local/sbin:/usr/local/bin:/sbin:/bin:/usr/sbin:/usr/bin"
+
+17 *	* * *	root    cd / && run-parts --report /etc/cron.hourly
+   # indented comment
+MAILTO=''
+@reboot root /usr/local/bin/warmup
+@daily  root date +\%F% first line%second line
+CRON_TZ=America/New_York
+0 9 * * 1-5 alice report
+0 9 * * 1-5
+61 * * * * root broken
+CRON_TZ=Mars/Olympus
+0 0 * * * bob cleanup
+`
+
+func TestParse(t *testing.T) {
+	crontab, err := Parse(strings.NewReader(systemCrontab), System)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crontab.Entries) != 5 {
+		t.Fatalf(`Parse() returned %d entries, expected 5`, len(crontab.Entries))
+	}
+
+	hourly := crontab.Entries[0]
+	if hourly.Line != 5 || hourly.User != "root" || hourly.Command != "cd / && run-parts --report /etc/cron.hourly" || hourly.Reboot {
+		t.Errorf(`entry = %+v`, hourly)
+	}
+	if s := hourly.Expression.String(); s != "0 17 * * * * *" {
+		t.Errorf(`entry.Expression = "%s"`, s)
+	}
+	if hourly.Env["SHELL"] != "/bin/sh" || hourly.Env["PATH"] != "/usr/local/sbin:/usr/local/bin:/sbin:/bin:/usr/sbin:/usr/bin" {
+		t.Errorf(`entry.Env = %v`, hourly.Env)
+	}
+	if _, ok := hourly.Env["MAILTO"]; ok {
+		t.Errorf(`entry.Env = %v, expected no MAILTO`, hourly.Env)
+	}
+
+	reboot := crontab.Entries[1]
+	if reboot.Line != 8 || !reboot.Reboot || reboot.Expression != nil || reboot.User != "root" || reboot.Command != "/usr/local/bin/warmup" {
+		t.Errorf(`entry = %+v`, reboot)
+	}
+	if mailto, ok := reboot.Env["MAILTO"]; !ok || mailto != "" {
+		t.Errorf(`entry.Env = %v, expected an empty MAILTO`, reboot.Env)
+	}
+
+	daily := crontab.Entries[2]
+	if daily.Command != "date +%F" || daily.Stdin != " first line\nsecond line" {
+		t.Errorf(`entry.Command, entry.Stdin = %q, %q`, daily.Command, daily.Stdin)
+	}
+
+	report := crontab.Entries[3]
+	if report.Line != 11 || report.User != "alice" || report.Env["CRON_TZ"] != "America/New_York" {
+		t.Errorf(`entry = %+v`, report)
+	}
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if next := report.Expression.Next(from); next.Format(time.RFC3339) != "2024-01-01T09:00:00-05:00" {
+		t.Errorf(`entry.Expression.Next() = "%s"`, next)
+	}
+
+	// An unknown time zone leaves the previous one in effect
+	cleanup := crontab.Entries[4]
+	if cleanup.Line != 15 || cleanup.Env["CRON_TZ"] != "America/New_York" || cleanup.Expression.Next(from).Hour() != 0 {
+		t.Errorf(`entry = %+v`, cleanup)
+	}
+
+	// Errors do not abort parsing
+	lines := []int{}
+	for _, err := range crontab.Errors {
+		lines = append(lines, err.Line)
+	}
+	if len(lines) != 3 || lines[0] != 12 || lines[1] != 13 || lines[2] != 14 {
+		t.Fatalf(`Parse() errors on lines %v, expected 12, 13 and 14`, lines)
+	}
+	var perr *cronexpr.ParseError
+	if !errors.As(crontab.Errors[0], &perr) || perr.Kind != cronexpr.ErrorMissingCommand {
+		t.Errorf(`Parse() error = "%s", expected a missing command`, crontab.Errors[0])
+	}
+	if !errors.As(crontab.Errors[1], &perr) || perr.Kind != cronexpr.ErrorSyntax || perr.Directive != "61" {
+		t.Errorf(`Parse() error = "%s", expected a syntax error`, crontab.Errors[1])
+	}
+	if crontab.Env["CRON_TZ"] != "America/New_York" {
+		t.Errorf(`crontab.Env = %v`, crontab.Env)
+	}
+}
+
+func TestParseUserCrontab(t *testing.T) {
+	text := "MAILTO=ops@example.com\r\n*/5 * * * * /usr/bin/backup --full\r\n@reboot\tstart-agent\r\n"
+	crontab, err := Parse(strings.NewReader(text), User)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crontab.Entries) != 2 || len(crontab.Errors) != 0 {
+		t.Fatalf(`Parse() = %d entries, %v`, len(crontab.Entries), crontab.Errors)
+	}
+	backup := crontab.Entries[0]
+	if backup.Line != 2 || backup.User != "" || backup.Command != "/usr/bin/backup --full" || backup.Env["MAILTO"] != "ops@example.com" {
+		t.Errorf(`entry = %+v`, backup)
+	}
+	if backup.Text != "*/5 * * * * /usr/bin/backup --full" {
+		t.Errorf(`entry.Text = %q`, backup.Text)
+	}
+	if reboot := crontab.Entries[1]; !reboot.Reboot || reboot.Command != "start-agent" {
+		t.Errorf(`entry = %+v`, reboot)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "backup")
+	if err := os.WriteFile(name, []byte("0 3 * * * root backup\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	crontab, err := Load(name, System)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crontab.Entries) != 1 || crontab.Entries[0].User != "root" {
+		t.Errorf(`Load() = %+v`, crontab)
+	}
+	if _, err = Load(filepath.Join(t.TempDir(), "missing"), System); err == nil {
+		t.Errorf(`Load() of a missing file returned no error`)
+	}
+}